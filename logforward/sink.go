@@ -0,0 +1,84 @@
+package logforward
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"prometheus_F670L/ont"
+	"sync"
+	"time"
+)
+
+// Sink receives forwarded ONT system log entries.
+type Sink interface {
+	Write(entry ont.LogEntry) error
+}
+
+// NewSink creates the sink named by kind: "stdout", "file" (JSON Lines
+// appended to target, ont_syslog.jsonl by default) or "syslog" (RFC 5424 sent
+// to target, e.g. udp://127.0.0.1:514). hostname is reported as the origin of
+// syslog messages.
+func NewSink(kind, target, hostname string) (Sink, error) {
+	switch kind {
+	case "stdout":
+		return &textSink{w: os.Stdout}, nil
+	case "file":
+		f, err := os.OpenFile(cmp.Or(target, "ont_syslog.jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonLinesSink{w: f}, nil
+	case "syslog":
+		return newSyslogSink(target, hostname)
+	default:
+		return nil, fmt.Errorf("unknown log sink %q", kind)
+	}
+}
+
+type textSink struct {
+	w io.Writer
+}
+
+func (s *textSink) Write(entry ont.LogEntry) error {
+	_, err := fmt.Fprintf(s.w, "%s [%s] [%s] %s\n", entryTime(entry).Format(time.RFC3339), entry.Level, entry.Module, entry.Message)
+	return err
+}
+
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+type jsonLogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Module  string    `json:"module"`
+	Message string    `json:"message"`
+}
+
+func (s *jsonLinesSink) Write(entry ont.LogEntry) error {
+	data, err := json.Marshal(jsonLogEntry{
+		Time:    entryTime(entry),
+		Level:   entry.Level,
+		Module:  entry.Module,
+		Message: entry.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// entryTime falls back to the current time for entries without a parsable timestamp.
+func entryTime(entry ont.LogEntry) time.Time {
+	if entry.Time.IsZero() {
+		return time.Now()
+	}
+	return entry.Time
+}
@@ -0,0 +1,116 @@
+package logforward
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"prometheus_F670L/ont"
+	"strings"
+	"time"
+)
+
+const (
+	syslogFacilityLocal0 = 16
+	syslogAppName        = "zte-ont"
+)
+
+// syslogSeverities maps ONT log levels to RFC 5424 severities.
+var syslogSeverities = map[string]int{
+	"emergency":     0,
+	"alert":         1,
+	"critical":      2,
+	"error":         3,
+	"warning":       4,
+	"notice":        5,
+	"informational": 6,
+	"info":          6,
+	"debug":         7,
+}
+
+// syslogSink sends RFC 5424 messages over UDP, or over TCP using octet
+// counting framing (RFC 6587).
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSink(target, hostname string) (*syslogSink, error) {
+	if !strings.Contains(target, "://") {
+		target = "udp://" + target
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "udp" && u.Scheme != "tcp" {
+		return nil, fmt.Errorf("unsupported syslog network %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("no syslog address configured")
+	}
+	return &syslogSink{
+		network:  u.Scheme,
+		address:  u.Host,
+		hostname: syslogField(hostname, 255),
+	}, nil
+}
+
+func (s *syslogSink) Write(entry ont.LogEntry) error {
+	msg := s.format(entry)
+	if s.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+
+	// Reconnect once, the receiver may have closed an idle TCP connection
+	var err error
+	for range 2 {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout(s.network, s.address, 10*time.Second); err != nil {
+				return err
+			}
+		}
+		if _, err = s.conn.Write([]byte(msg)); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *syslogSink) format(entry ont.LogEntry) string {
+	severity, ok := syslogSeverities[strings.ToLower(entry.Level)]
+	if !ok {
+		severity = syslogSeverities["notice"]
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogFacilityLocal0*8+severity,
+		entryTime(entry).Format(time.RFC3339),
+		s.hostname,
+		syslogAppName,
+		syslogField(entry.Module, 32),
+		entry.Message,
+	)
+}
+
+// syslogField turns val into a valid RFC 5424 header field: printable ASCII
+// without spaces, at most maxLen characters, or "-" when empty.
+func syslogField(val string, maxLen int) string {
+	var b strings.Builder
+	for _, r := range val {
+		if r > 32 && r < 127 {
+			b.WriteRune(r)
+		}
+	}
+	field := b.String()
+	if field == "" {
+		return "-"
+	}
+	if len(field) > maxLen {
+		field = field[:maxLen]
+	}
+	return field
+}
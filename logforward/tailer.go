@@ -0,0 +1,113 @@
+package logforward
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"prometheus_F670L/ont"
+	"time"
+)
+
+// Tailer polls the ONT system log and forwards entries it hasn't seen yet.
+type Tailer struct {
+	session   *ont.Session
+	sink      Sink
+	interval  time.Duration
+	stateFile string
+	cursor    ont.LogCursor
+	observers []func([]ont.LogEntry)
+}
+
+// NewTailer creates a tailer polling every interval. When stateFile is set the
+// last forwarded entry is stored there, so a restarted exporter resumes where
+// it stopped; otherwise entries already in the log at startup are skipped.
+func NewTailer(session *ont.Session, sink Sink, interval time.Duration, stateFile string) *Tailer {
+	return &Tailer{
+		session:   session,
+		sink:      sink,
+		interval:  interval,
+		stateFile: stateFile,
+	}
+}
+
+// AddObserver registers fn to be called with every system log loaded, so
+// other consumers don't have to load the log themselves. It must be called
+// before Run.
+func (t *Tailer) AddObserver(fn func([]ont.LogEntry)) {
+	t.observers = append(t.observers, fn)
+}
+
+// Run polls the system log until the process exits.
+func (t *Tailer) Run() {
+	skipExisting := t.stateFile == ""
+	if !skipExisting {
+		if err := t.loadState(); err != nil {
+			log.Printf("[LogForward] Error loading state: %v", err)
+		}
+	}
+
+	for {
+		if err := t.poll(skipExisting); err != nil {
+			log.Printf("[LogForward] Error forwarding system log: %v", err)
+		} else {
+			skipExisting = false
+		}
+		time.Sleep(t.interval)
+	}
+}
+
+func (t *Tailer) poll(skipExisting bool) error {
+	t.session.Lock()
+	entries, err := t.session.LoadSystemLog()
+	t.session.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, observe := range t.observers {
+		observe(entries)
+	}
+
+	// Advance a copy, so entries that fail to be written are retried on the
+	// next poll
+	cursor := t.cursor
+	next := cursor.Next(entries)
+	if skipExisting {
+		t.cursor = cursor
+		return t.saveState()
+	}
+
+	for _, entry := range next {
+		if err := t.sink.Write(entry); err != nil {
+			if saveErr := t.saveState(); saveErr != nil {
+				log.Printf("[LogForward] Error saving state: %v", saveErr)
+			}
+			return err
+		}
+		t.cursor.Last = &entry
+	}
+	t.cursor = cursor
+	return t.saveState()
+}
+
+func (t *Tailer) loadState() error {
+	data, err := os.ReadFile(t.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &t.cursor)
+}
+
+func (t *Tailer) saveState() error {
+	if t.stateFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(t.cursor)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.stateFile, data, 0o644)
+}
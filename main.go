@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"prometheus_F670L/logforward"
 	"prometheus_F670L/ont"
 	internalPrometheus "prometheus_F670L/prometheus"
+	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -27,9 +32,41 @@ func main() {
 	}
 
 	log.Println("Login succeeded")
+
+	diag := internalPrometheus.NewDiagProber(session)
+	if diag != nil {
+		go diag.Run()
+
+		log.Println("Running ONT diagnostics in the background")
+	}
+
 	log.Println("Loading ONT Collector")
 
-	collector := internalPrometheus.NewONTCollector(session)
+	collector := internalPrometheus.NewONTCollector(session, diag)
+
+	if sinkKind := os.Getenv("ONT_LOG_FORWARD"); sinkKind != "" {
+		hostname := session.Endpoint
+		if u, err := url.Parse(session.Endpoint); err == nil {
+			hostname = u.Hostname()
+		}
+
+		sink, err := logforward.NewSink(
+			sinkKind,
+			os.Getenv("ONT_LOG_TARGET"),
+			hostname,
+		)
+		if err != nil {
+			log.Fatalf("Log forwarding setup failed: %v", err)
+		}
+
+		interval, _ := strconv.Atoi(cmp.Or(os.Getenv("ONT_LOG_INTERVAL"), "60"))
+		tailer := logforward.NewTailer(session, sink, time.Duration(max(interval, 1))*time.Second, os.Getenv("ONT_LOG_STATE_FILE"))
+		tailer.AddObserver(collector.ObserveSystemLog)
+		go tailer.Run()
+
+		log.Printf("Forwarding ONT system log to %s", sinkKind)
+	}
+
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(collector)
 
@@ -0,0 +1,301 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PingResult struct {
+	Target       string
+	State        string
+	SuccessCount int
+	FailureCount int
+	MinRTT       time.Duration
+	AvgRTT       time.Duration
+	MaxRTT       time.Duration
+}
+
+// LossRatio returns the share of echo requests that got no reply. ok is false
+// when the ONT reported no requests at all, so the loss is unknown.
+func (r PingResult) LossRatio() (ratio float64, ok bool) {
+	total := r.SuccessCount + r.FailureCount
+	if total == 0 {
+		return 0, false
+	}
+	return float64(r.FailureCount) / float64(total), true
+}
+
+type TracerouteHop struct {
+	Hop     int
+	Host    string
+	Address string
+	RTTs    []time.Duration
+}
+
+type TracerouteResult struct {
+	Target       string
+	State        string
+	ResponseTime time.Duration
+	Hops         []TracerouteHop
+}
+
+// DiagnosisError is returned when the ONT completed a diagnosis with an error
+// state, e.g. "Error_CannotResolveHostName", as opposed to the diagnosis page
+// itself failing to load.
+type DiagnosisError struct {
+	State string
+}
+
+func (e *DiagnosisError) Error() string {
+	return "diagnosis failed: " + e.State
+}
+
+type diagnosisResponse struct {
+	XMLName        xml.Name        `xml:"ajax_response_xml_root"`
+	IFERRORPARAM   string          `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE    string          `xml:"IF_ERRORTYPE"`
+	IFERRORSTR     string          `xml:"IF_ERRORSTR"`
+	IFERRORID      string          `xml:"IF_ERRORID"`
+	OBJPINGDIAGID  diagnosisObject `xml:"OBJ_PING_DIAG_ID"`
+	OBJTRACEDIAGID diagnosisObject `xml:"OBJ_TRACEROUTE_DIAG_ID"`
+	OBJTRACEHOPSID diagnosisObject `xml:"OBJ_TRACEROUTE_HOPS_ID"`
+}
+
+type diagnosisObject struct {
+	Instances []diagnosisInstance `xml:"Instance"`
+}
+
+type diagnosisInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (inst diagnosisInstance) get(name string) string {
+	for i, n := range inst.ParaName {
+		if n == name && i < len(inst.ParaValue) {
+			return inst.ParaValue[i]
+		}
+	}
+	return ""
+}
+
+// first returns the first instance of the object, or an empty one.
+func (o diagnosisObject) first() diagnosisInstance {
+	if len(o.Instances) == 0 {
+		return diagnosisInstance{}
+	}
+	return o.Instances[0]
+}
+
+const (
+	diagnosisPollInterval = time.Second
+	diagnosisMenuTag      = "diagnosis"
+
+	tracerouteMaxHops      = 20
+	tracerouteTries        = 3
+	tracerouteProbeTimeout = 500 * time.Millisecond
+)
+
+// Ping runs count ICMP echo requests from the ONT to target and waits for the
+// result. The session is only locked around each request, so Ping must be
+// called without holding it.
+func (s *Session) Ping(target string, count int) (*PingResult, error) {
+	form := url.Values{
+		"Host":                {target},
+		"NumberOfRepetitions": {strconv.Itoa(count)},
+		"DataBlockSize":       {"56"},
+		"Timeout":             {"1000"},
+	}
+	timeout := time.Duration(count)*2*time.Second + 10*time.Second
+	result, err := s.runDiagnosis("diagnosis_ping_lua.lua", form, timeout, func(r *diagnosisResponse) string {
+		return r.OBJPINGDIAGID.first().get("DiagnosticsState")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inst := result.OBJPINGDIAGID.first()
+	ping := &PingResult{
+		Target: target,
+		State:  inst.get("DiagnosticsState"),
+	}
+	ping.SuccessCount, _ = strconv.Atoi(inst.get("SuccessCount"))
+	ping.FailureCount, _ = strconv.Atoi(inst.get("FailureCount"))
+	ping.MinRTT = parseMilliseconds(inst.get("MinimumResponseTime"))
+	ping.AvgRTT = parseMilliseconds(inst.get("AverageResponseTime"))
+	ping.MaxRTT = parseMilliseconds(inst.get("MaximumResponseTime"))
+	return ping, nil
+}
+
+// Traceroute traces the route from the ONT to target and waits for the
+// result. Like Ping it locks the session itself.
+func (s *Session) Traceroute(target string) (*TracerouteResult, error) {
+	form := url.Values{
+		"Host":          {target},
+		"MaxHopCount":   {strconv.Itoa(tracerouteMaxHops)},
+		"NumberOfTries": {strconv.Itoa(tracerouteTries)},
+		"Timeout":       {strconv.FormatInt(tracerouteProbeTimeout.Milliseconds(), 10)},
+		"DataBlockSize": {"38"},
+	}
+	timeout := tracerouteMaxHops*tracerouteTries*tracerouteProbeTimeout + 10*time.Second
+	result, err := s.runDiagnosis("diagnosis_traceroute_lua.lua", form, timeout, func(r *diagnosisResponse) string {
+		return r.OBJTRACEDIAGID.first().get("DiagnosticsState")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inst := result.OBJTRACEDIAGID.first()
+	trace := &TracerouteResult{
+		Target:       target,
+		State:        inst.get("DiagnosticsState"),
+		ResponseTime: parseMilliseconds(inst.get("ResponseTime")),
+	}
+	for i, hopInst := range result.OBJTRACEHOPSID.Instances {
+		hop := TracerouteHop{
+			Hop:     i + 1,
+			Host:    hopInst.get("HopHost"),
+			Address: hopInst.get("HopHostAddress"),
+		}
+		for _, rtt := range strings.Split(hopInst.get("HopRTTimes"), ",") {
+			if rtt = strings.TrimSpace(rtt); rtt != "" && rtt != "*" {
+				hop.RTTs = append(hop.RTTs, parseMilliseconds(rtt))
+			}
+		}
+		trace.Hops = append(trace.Hops, hop)
+	}
+	return trace, nil
+}
+
+// runDiagnosis starts a diagnosis on the given page and polls it until state
+// reports it is no longer running. The session is locked around each request
+// only, never while waiting for the ONT.
+func (s *Session) runDiagnosis(tag string, form url.Values, timeout time.Duration, state func(*diagnosisResponse) string) (*diagnosisResponse, error) {
+	s.Lock()
+	err := s.startDiagnosis(tag, form)
+	s.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(diagnosisPollInterval)
+
+		s.Lock()
+		result, err := s.loadDiagnosis(tag)
+		s.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		switch current := state(result); current {
+		case "Requested", "":
+			continue
+		case "Complete", "Completed":
+			return result, nil
+		default:
+			return nil, &DiagnosisError{State: current}
+		}
+	}
+	return nil, errors.New("diagnosis timed out")
+}
+
+func (s *Session) startDiagnosis(tag string, form url.Values) error {
+	token, err := s.getSessionTmpToken(diagnosisMenuTag)
+	if err != nil {
+		return err
+	}
+
+	form.Set("IF_ACTION", "Apply")
+	form.Set("DiagnosticsState", "Requested")
+	form.Set("_sessionTOKEN", token)
+
+	resp, err := s.Post(s.Endpoint+"/?_type=menuData&_tag="+tag, "application/x-www-form-urlencoded; charset=UTF-8", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var started diagnosisResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&started); err != nil {
+		return err
+	}
+	if started.IFERRORSTR != "SUCC" {
+		return errors.New(started.IFERRORSTR)
+	}
+	return nil
+}
+
+func (s *Session) loadDiagnosis(tag string) (*diagnosisResponse, error) {
+	// Other pages may have been opened since the last poll, so trigger the
+	// diagnosis menu again
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=" + diagnosisMenuTag + "&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	resp, err := s.Get(s.Endpoint + "/?_type=menuData&_tag=" + tag + "&_=" + strconv.FormatInt(time.Now().Unix(), 10))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result diagnosisResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return &result, nil
+}
+
+var sessionTmpTokenPattern = regexp.MustCompile(`_sessionTmpToken\s*=\s*"([^"]*)"`)
+
+// getSessionTmpToken opens the menu and returns the token the web UI embeds
+// in it for submitting forms, which is written as \xNN escapes.
+func (s *Session) getSessionTmpToken(menuTag string) (string, error) {
+	resp, err := s.Get(s.Endpoint + "/?_type=menuView&_tag=" + menuTag + "&Menu3Location=0&_=" + strconv.FormatInt(time.Now().Unix(), 10))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	match := sessionTmpTokenPattern.FindSubmatch(body)
+	if match == nil {
+		return "", errors.New("session token not found")
+	}
+	token, err := strconv.Unquote(`"` + string(match[1]) + `"`)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func parseMilliseconds(val string) time.Duration {
+	ms, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,16 @@
+package ont
+
+// PartialError is returned together with the loaded data when only an
+// optional page of a loader failed, e.g. the IPv6 pages on IPv4 only firmware.
+type PartialError struct {
+	Page string
+	Err  error
+}
+
+func (e *PartialError) Error() string {
+	return "loading " + e.Page + ": " + e.Err.Error()
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,74 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ALGSettings maps the lower-case ALG name (ftp, sip, pptp, ...) to whether it is enabled.
+type ALGSettings map[string]int
+
+type algSettingsResponse struct {
+	XMLName         xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM    string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE     string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR      string   `xml:"IF_ERRORSTR"`
+	IFERRORID       string   `xml:"IF_ERRORID"`
+	OBJALGABILITYID struct {
+		Instance algSettingsInstance `xml:"Instance"`
+	} `xml:"OBJ_ALGABILITY_ID"`
+}
+
+type algSettingsInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadALGSettings() (ALGSettings, error) {
+	// Trigger the menu to load the ALG settings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=alg&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the ALG settings
+	url := s.Endpoint + "/?_type=menuData&_tag=sec_alg_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result algSettingsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r algSettingsResponse) Convert() ALGSettings {
+	s := ALGSettings{}
+	for i, name := range r.OBJALGABILITYID.Instance.ParaName {
+		if i >= len(r.OBJALGABILITYID.Instance.ParaValue) {
+			continue
+		}
+		// Parameters are named FtpEnable, SipEnable, PptpEnable, ...
+		alg, ok := strings.CutSuffix(name, "Enable")
+		if !ok || alg == "" {
+			continue
+		}
+		s[strings.ToLower(alg)], _ = strconv.Atoi(r.OBJALGABILITYID.Instance.ParaValue[i])
+	}
+	return s
+}
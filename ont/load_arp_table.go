@@ -0,0 +1,86 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ArpEntry struct {
+	IPAddress  string
+	MACAddress string
+	Interface  string
+	Type       string
+}
+
+type arpTableResponse struct {
+	XMLName       xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM  string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE   string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR    string   `xml:"IF_ERRORSTR"`
+	IFERRORID     string   `xml:"IF_ERRORID"`
+	OBJARPTABLEID struct {
+		Instances []arpTableInstance `xml:"Instance"`
+	} `xml:"OBJ_ARP_TABLE_ID"`
+}
+
+type arpTableInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadArpTable() ([]ArpEntry, error) {
+	// Trigger the menu to load the ARP table
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=arpTable&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the ARP table
+	url := s.Endpoint + "/?_type=menuData&_tag=status_arp_table_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result arpTableResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r arpTableResponse) Convert() []ArpEntry {
+	var entries []ArpEntry
+	for _, inst := range r.OBJARPTABLEID.Instances {
+		entry := ArpEntry{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "IPAddress":
+				entry.IPAddress = val
+			case "MACAddress":
+				entry.MACAddress = val
+			case "Interface":
+				entry.Interface = val
+			case "Type":
+				entry.Type = val
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,100 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type DDNSStatus struct {
+	Enable       int
+	Provider     string
+	Hostname     string
+	WANCName     string
+	Status       string
+	LastUpdate   time.Time
+	RegisteredIP string
+}
+
+// UpdateSucceeded reports whether the last update was accepted by the provider.
+func (s DDNSStatus) UpdateSucceeded() bool {
+	switch s.Status {
+	case "Success", "Succeeded", "Updated", "good", "nochg":
+		return true
+	}
+	return false
+}
+
+type ddnsStatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJDDNSID    struct {
+		Instance ddnsStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_DDNS_ID"`
+}
+
+type ddnsStatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadDDNSStatus() (*DDNSStatus, error) {
+	// Trigger the menu to load the DDNS status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ddns&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the DDNS status
+	url := s.Endpoint + "/?_type=menuData&_tag=app_ddns_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result ddnsStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r ddnsStatusResponse) Convert() *DDNSStatus {
+	s := DDNSStatus{}
+	for i, name := range r.OBJDDNSID.Instance.ParaName {
+		if i >= len(r.OBJDDNSID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJDDNSID.Instance.ParaValue[i]
+		switch name {
+		case "Enable":
+			s.Enable, _ = strconv.Atoi(val)
+		case "Provider":
+			s.Provider = val
+		case "DomainName":
+			s.Hostname = val
+		case "WANCName":
+			s.WANCName = val
+		case "UpdateStatus":
+			s.Status = val
+		case "LastUpdateTime":
+			s.LastUpdate = parseDeviceTime(val)
+		case "RegisteredIP":
+			s.RegisteredIP = val
+		}
+	}
+	return &s
+}
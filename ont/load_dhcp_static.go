@@ -0,0 +1,71 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type LanDHCPStaticBinding struct {
+	InstID   string
+	MACAddr  string
+	IPAddr   string
+	HostName string
+}
+
+type LanDHCPStaticBindingsResponse struct {
+	XMLName       xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM  string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE   string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR    string   `xml:"IF_ERRORSTR"`
+	IFERRORID     string   `xml:"IF_ERRORID"`
+	OBJDHCPBINDID struct {
+		Instances []dhcpHostInstance `xml:"Instance"`
+	} `xml:"OBJ_DHCPBIND_ID"`
+}
+
+func (s *Session) LoadLanDHCPStaticBindings() ([]LanDHCPStaticBinding, error) {
+	// Trigger the menu to load the DHCP static bindings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=lanMgrIpv4&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the DHCP static bindings
+	url := s.Endpoint + "/?_type=menuData&_tag=Localnet_LanMgrIpv4_DHCPBinding_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result LanDHCPStaticBindingsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r LanDHCPStaticBindingsResponse) Convert() []LanDHCPStaticBinding {
+	var bindings []LanDHCPStaticBinding
+	for _, inst := range r.OBJDHCPBINDID.Instances {
+		m := inst.ToMap()
+		binding := LanDHCPStaticBinding{
+			InstID:   m["_InstID"],
+			MACAddr:  m["MACAddr"],
+			IPAddr:   m["IPAddr"],
+			HostName: m["HostName"],
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
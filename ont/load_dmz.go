@@ -0,0 +1,79 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type DMZ struct {
+	Enable         int
+	WANCName       string
+	InternalClient string
+}
+
+type dmzResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJDMZID     struct {
+		Instance dmzInstance `xml:"Instance"`
+	} `xml:"OBJ_DMZ_ID"`
+}
+
+type dmzInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadDMZ() (*DMZ, error) {
+	// Trigger the menu to load the DMZ settings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=dmzHost&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the DMZ settings
+	url := s.Endpoint + "/?_type=menuData&_tag=Internet_DMZ_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result dmzResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r dmzResponse) Convert() *DMZ {
+	s := DMZ{}
+	for i, name := range r.OBJDMZID.Instance.ParaName {
+		if i >= len(r.OBJDMZID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJDMZID.Instance.ParaValue[i]
+		switch name {
+		case "Enable":
+			s.Enable, _ = strconv.Atoi(val)
+		case "WANCName":
+			s.WANCName = val
+		case "InternalHost":
+			s.InternalClient = val
+		}
+	}
+	return &s
+}
@@ -0,0 +1,76 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type FirewallSettings struct {
+	Level            string
+	AntiAttackEnable int
+}
+
+type firewallResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJFWLEVELID struct {
+		Instance firewallInstance `xml:"Instance"`
+	} `xml:"OBJ_FWLEVEL_ID"`
+}
+
+type firewallInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadFirewallSettings() (*FirewallSettings, error) {
+	// Trigger the menu to load the firewall settings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=firewall&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the firewall settings
+	url := s.Endpoint + "/?_type=menuData&_tag=sec_firewall_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result firewallResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r firewallResponse) Convert() *FirewallSettings {
+	s := FirewallSettings{}
+	for i, name := range r.OBJFWLEVELID.Instance.ParaName {
+		if i >= len(r.OBJFWLEVELID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJFWLEVELID.Instance.ParaValue[i]
+		switch name {
+		case "FirewallLevel":
+			s.Level = val
+		case "AntiHackEnable":
+			s.AntiAttackEnable, _ = strconv.Atoi(val)
+		}
+	}
+	return &s
+}
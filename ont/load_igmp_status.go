@@ -0,0 +1,114 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type IGMPGroup struct {
+	GroupAddress  string
+	SourceAddress string
+	Interface     string
+	MemberPorts   string
+	Members       int
+}
+
+type IGMPStatus struct {
+	SnoopingEnable    int
+	ProxyEnable       int
+	UpstreamInterface string
+	Groups            []IGMPGroup
+}
+
+type igmpStatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJIGMPCFGID struct {
+		Instance igmpStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_IGMP_CFG_ID"`
+	OBJIGMPGROUPID struct {
+		Instances []igmpStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_IGMP_GROUP_ID"`
+}
+
+type igmpStatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadIGMPStatus() (*IGMPStatus, error) {
+	// Trigger the menu to load the IGMP status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=igmp&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the IGMP status
+	url := s.Endpoint + "/?_type=menuData&_tag=app_igmp_status_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result igmpStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r igmpStatusResponse) Convert() *IGMPStatus {
+	status := &IGMPStatus{}
+	for i, name := range r.OBJIGMPCFGID.Instance.ParaName {
+		if i >= len(r.OBJIGMPCFGID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJIGMPCFGID.Instance.ParaValue[i]
+		switch name {
+		case "SnoopingEnable":
+			status.SnoopingEnable, _ = strconv.Atoi(val)
+		case "ProxyEnable":
+			status.ProxyEnable, _ = strconv.Atoi(val)
+		case "UpstreamWANCName":
+			status.UpstreamInterface = val
+		}
+	}
+
+	for _, inst := range r.OBJIGMPGROUPID.Instances {
+		group := IGMPGroup{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "GroupAddr":
+				group.GroupAddress = val
+			case "SourceAddr":
+				group.SourceAddress = val
+			case "Interface":
+				group.Interface = val
+			case "MemberPorts":
+				group.MemberPorts = val
+			case "MemberNum":
+				group.Members, _ = strconv.Atoi(val)
+			}
+		}
+		status.Groups = append(status.Groups, group)
+	}
+	return status
+}
@@ -0,0 +1,194 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type WanIPv6Status struct {
+	InstID                  string
+	WANCName                string
+	ConnStatus6             string
+	GlobalAddress           string
+	Gateway                 string
+	DNS1                    string
+	DNS2                    string
+	DelegatedPrefix         string
+	PrefixValidLifetime     int
+	PrefixPreferredLifetime int
+}
+
+type LanIPv6Status struct {
+	Prefix       string
+	PrefixOrigin string
+	RAEnable     int
+	DHCPv6Mode   string
+}
+
+type IPv6Status struct {
+	WAN []WanIPv6Status
+	LAN *LanIPv6Status
+}
+
+type wanIPv6StatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	IDWANCONFIG  struct {
+		Instances []ipv6StatusInstance `xml:"Instance"`
+	} `xml:"ID_WAN_COMFIG"`
+}
+
+type lanIPv6StatusResponse struct {
+	XMLName          xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM     string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE      string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR       string   `xml:"IF_ERRORSTR"`
+	IFERRORID        string   `xml:"IF_ERRORID"`
+	OBJLANIPV6INFOID struct {
+		Instance ipv6StatusInstance `xml:"Instance"`
+	} `xml:"OBJ_LANIPV6INFO_ID"`
+}
+
+type ipv6StatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+// LoadIPv6Status loads the WAN and LAN IPv6 status. When only the LAN page
+// fails, the WAN status is returned with LAN nil and a *PartialError.
+func (s *Session) LoadIPv6Status() (*IPv6Status, error) {
+	wan, err := s.loadWanIPv6Status()
+	if err != nil {
+		return nil, err
+	}
+	status := &IPv6Status{WAN: wan}
+
+	lan, err := s.loadLanIPv6Status()
+	if err != nil {
+		return status, &PartialError{Page: "IPv6 LAN status", Err: err}
+	}
+	status.LAN = lan
+	return status, nil
+}
+
+func (s *Session) loadWanIPv6Status() ([]WanIPv6Status, error) {
+	// Trigger the menu to load the IPv6 WAN status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ipv6WanStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the IPv6 WAN status
+	url := s.Endpoint + "/?_type=menuData&_tag=wan_internetstatus_ipv6_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result wanIPv6StatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (s *Session) loadLanIPv6Status() (*LanIPv6Status, error) {
+	// Trigger the menu to load the IPv6 LAN status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=lanMgrIpv6&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the IPv6 LAN status
+	url := s.Endpoint + "/?_type=menuData&_tag=Localnet_LanMgrIpv6_Status_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result lanIPv6StatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r wanIPv6StatusResponse) Convert() []WanIPv6Status {
+	var statuses []WanIPv6Status
+	for _, inst := range r.IDWANCONFIG.Instances {
+		status := WanIPv6Status{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				status.InstID = val
+			case "WANCName":
+				status.WANCName = val
+			case "ConnStatus6":
+				status.ConnStatus6 = val
+			case "GlobalAddress":
+				status.GlobalAddress = val
+			case "Gateway6":
+				status.Gateway = val
+			case "Dns1v6":
+				status.DNS1 = val
+			case "Dns2v6":
+				status.DNS2 = val
+			case "PdPrefix":
+				status.DelegatedPrefix = val
+			case "PdValidTime":
+				status.PrefixValidLifetime, _ = strconv.Atoi(val)
+			case "PdPreferTime":
+				status.PrefixPreferredLifetime, _ = strconv.Atoi(val)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (r lanIPv6StatusResponse) Convert() *LanIPv6Status {
+	s := &LanIPv6Status{}
+	for i, name := range r.OBJLANIPV6INFOID.Instance.ParaName {
+		if i >= len(r.OBJLANIPV6INFOID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJLANIPV6INFOID.Instance.ParaValue[i]
+		switch name {
+		case "Prefix":
+			s.Prefix = val
+		case "PrefixOrigin":
+			s.PrefixOrigin = val
+		case "RAEnable":
+			s.RAEnable, _ = strconv.Atoi(val)
+		case "DHCPv6Mode":
+			s.DHCPv6Mode = val
+		}
+	}
+	return s
+}
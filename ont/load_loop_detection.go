@@ -0,0 +1,123 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type LoopDetectionPort struct {
+	InstID string
+	Port   string
+	Enable int
+	// Status is "Loopback" while a loop is detected on the port, "Normal"
+	// otherwise
+	Status string
+	// Isolated is 1 while the ONT has shut the port down because of a loop
+	Isolated int
+}
+
+// Looped reports whether a switching loop is currently detected on the port.
+// A port can stay isolated after the loop is gone, see Isolated.
+func (p LoopDetectionPort) Looped() bool {
+	return p.Status == "Loopback"
+}
+
+type LoopDetection struct {
+	Enable int
+	// RecoveryInterval is the time in seconds after which an isolated port is
+	// re-enabled, 0 keeps it shut down until the loop is cleared manually
+	RecoveryInterval int
+	Ports            []LoopDetectionPort
+}
+
+type loopDetectionResponse struct {
+	XMLName         xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM    string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE     string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR      string   `xml:"IF_ERRORSTR"`
+	IFERRORID       string   `xml:"IF_ERRORID"`
+	OBJLOOPDETECTID struct {
+		Instance loopDetectionInstance `xml:"Instance"`
+	} `xml:"OBJ_LOOPDETECT_ID"`
+	OBJLOOPDETECTPORTID struct {
+		Instances []loopDetectionInstance `xml:"Instance"`
+	} `xml:"OBJ_LOOPDETECTPORT_ID"`
+}
+
+type loopDetectionInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadLoopDetection() (*LoopDetection, error) {
+	// Trigger the menu to load the loopback detection
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=loopDetect&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the loopback detection
+	url := s.Endpoint + "/?_type=menuData&_tag=loop_detect_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result loopDetectionResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r loopDetectionResponse) Convert() *LoopDetection {
+	detection := &LoopDetection{}
+	for i, name := range r.OBJLOOPDETECTID.Instance.ParaName {
+		if i >= len(r.OBJLOOPDETECTID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJLOOPDETECTID.Instance.ParaValue[i]
+		switch name {
+		case "Enable":
+			detection.Enable, _ = strconv.Atoi(val)
+		case "RecoveryInterval":
+			detection.RecoveryInterval, _ = strconv.Atoi(val)
+		}
+	}
+
+	for _, inst := range r.OBJLOOPDETECTPORTID.Instances {
+		port := LoopDetectionPort{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				port.InstID = val
+			case "PortName":
+				port.Port = val
+			case "Enable":
+				port.Enable, _ = strconv.Atoi(val)
+			case "LoopStatus":
+				port.Status = val
+			case "PortIsolated":
+				port.Isolated, _ = strconv.Atoi(val)
+			}
+		}
+		detection.Ports = append(detection.Ports, port)
+	}
+
+	return detection
+}
@@ -0,0 +1,139 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type MacFilterRule struct {
+	InstID     string
+	Name       string
+	MACAddress string
+	// Scope is either "wlan" or "lan"
+	Scope string
+	// Mode is "allow" or "deny", depending on the filter's list mode
+	Mode string
+}
+
+type MacFilters struct {
+	WlanEnable int
+	LanEnable  int
+	Rules      []MacFilterRule
+}
+
+type macFilterResponse struct {
+	XMLName           xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM      string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE       string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR        string   `xml:"IF_ERRORSTR"`
+	IFERRORID         string   `xml:"IF_ERRORID"`
+	OBJMACFILTERCFGID struct {
+		Instances []macFilterInstance `xml:"Instance"`
+	} `xml:"OBJ_MACFILTER_CFG_ID"`
+	OBJMACFILTERID struct {
+		Instances []macFilterInstance `xml:"Instance"`
+	} `xml:"OBJ_MACFILTER_ID"`
+}
+
+type macFilterInstance struct {
+	Params []struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	} `xml:",any"`
+}
+
+func (inst *macFilterInstance) ToMap() map[string]string {
+	m := make(map[string]string)
+	var lastKey string
+	for _, p := range inst.Params {
+		if p.XMLName.Local == "ParaName" {
+			lastKey = p.Value
+		} else if p.XMLName.Local == "ParaValue" && lastKey != "" {
+			m[lastKey] = p.Value
+			lastKey = ""
+		}
+	}
+	return m
+}
+
+func (s *Session) LoadMacFilters() (*MacFilters, error) {
+	// Trigger the menu to load the MAC filters
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=macFilter&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the MAC filters
+	url := s.Endpoint + "/?_type=menuData&_tag=sec_macfilter_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result macFilterResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r macFilterResponse) Convert() *MacFilters {
+	filters := &MacFilters{}
+
+	// One config instance per scope holds the enable flag and list mode
+	modes := make(map[string]string)
+	for _, inst := range r.OBJMACFILTERCFGID.Instances {
+		m := inst.ToMap()
+		enable, _ := strconv.Atoi(m["Enable"])
+		scope := macFilterScope(m["Type"])
+		switch scope {
+		case "wlan":
+			filters.WlanEnable = enable
+		case "lan":
+			filters.LanEnable = enable
+		}
+		modes[scope] = macFilterMode(m["Mode"])
+	}
+
+	for _, inst := range r.OBJMACFILTERID.Instances {
+		m := inst.ToMap()
+		scope := macFilterScope(m["Type"])
+		filters.Rules = append(filters.Rules, MacFilterRule{
+			InstID:     m["_InstID"],
+			Name:       m["Name"],
+			MACAddress: m["MACAddress"],
+			Scope:      scope,
+			Mode:       modes[scope],
+		})
+	}
+	return filters
+}
+
+func macFilterScope(val string) string {
+	switch val {
+	case "WLAN", "wlan", "1":
+		return "wlan"
+	default:
+		return "lan"
+	}
+}
+
+func macFilterMode(val string) string {
+	switch val {
+	case "Allow", "allow", "White", "1":
+		return "allow"
+	default:
+		return "deny"
+	}
+}
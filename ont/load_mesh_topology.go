@@ -0,0 +1,139 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type MeshAgent struct {
+	InstID        string
+	MACAddress    string
+	DeviceName    string
+	Role          string
+	IPAddress     string
+	ParentMAC     string
+	BackhaulType  string
+	BackhaulRate  int // Mbps
+	BackhaulRSSI  int
+	ClientsNumber int
+}
+
+type MeshClient struct {
+	MACAddress string
+	AgentMAC   string
+	Band       string
+}
+
+type MeshTopology struct {
+	Agents  []MeshAgent
+	Clients []MeshClient
+}
+
+type meshTopologyResponse struct {
+	XMLName        xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM   string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE    string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR     string   `xml:"IF_ERRORSTR"`
+	IFERRORID      string   `xml:"IF_ERRORID"`
+	OBJMESHAGENTID struct {
+		Instances []meshTopologyInstance `xml:"Instance"`
+	} `xml:"OBJ_MESH_AGENT_ID"`
+	OBJMESHCLIENTID struct {
+		Instances []meshTopologyInstance `xml:"Instance"`
+	} `xml:"OBJ_MESH_CLIENT_ID"`
+}
+
+type meshTopologyInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadMeshTopology() (*MeshTopology, error) {
+	// Trigger the menu to load the mesh topology
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=meshTopology&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the mesh topology
+	url := s.Endpoint + "/?_type=menuData&_tag=mesh_topology_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result meshTopologyResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r meshTopologyResponse) Convert() *MeshTopology {
+	topology := &MeshTopology{}
+
+	for _, inst := range r.OBJMESHAGENTID.Instances {
+		agent := MeshAgent{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				agent.InstID = val
+			case "ALMac":
+				agent.MACAddress = val
+			case "DeviceName":
+				agent.DeviceName = val
+			case "Role":
+				agent.Role = val
+			case "IPAddress":
+				agent.IPAddress = val
+			case "ParentALMac":
+				agent.ParentMAC = val
+			case "BackhaulType":
+				agent.BackhaulType = val
+			case "BackhaulRate":
+				agent.BackhaulRate, _ = strconv.Atoi(val)
+			case "BackhaulRSSI":
+				agent.BackhaulRSSI, _ = strconv.Atoi(val)
+			case "ClientsNum":
+				agent.ClientsNumber, _ = strconv.Atoi(val)
+			}
+		}
+		topology.Agents = append(topology.Agents, agent)
+	}
+
+	for _, inst := range r.OBJMESHCLIENTID.Instances {
+		client := MeshClient{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "MACAddress":
+				client.MACAddress = val
+			case "ALMac":
+				client.AgentMAC = val
+			case "Band":
+				client.Band = val
+			}
+		}
+		topology.Clients = append(topology.Clients, client)
+	}
+
+	return topology
+}
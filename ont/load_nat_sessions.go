@@ -0,0 +1,85 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type NatSessions struct {
+	Active int
+	// Max is 0 when the firmware does not report the table size
+	Max int
+	// Protocols holds the active sessions per protocol, when the firmware reports them
+	Protocols map[string]int
+}
+
+type natSessionsResponse struct {
+	XMLName         xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM    string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE     string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR      string   `xml:"IF_ERRORSTR"`
+	IFERRORID       string   `xml:"IF_ERRORID"`
+	OBJNATSESSIONID struct {
+		Instance natSessionsInstance `xml:"Instance"`
+	} `xml:"OBJ_NAT_SESSION_ID"`
+}
+
+type natSessionsInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadNatSessions() (*NatSessions, error) {
+	// Trigger the menu to load the NAT session information
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=natSession&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the NAT session information
+	url := s.Endpoint + "/?_type=menuData&_tag=status_nat_session_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result natSessionsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r natSessionsResponse) Convert() *NatSessions {
+	s := NatSessions{Protocols: make(map[string]int)}
+	for i, name := range r.OBJNATSESSIONID.Instance.ParaName {
+		if i >= len(r.OBJNATSESSIONID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJNATSESSIONID.Instance.ParaValue[i]
+		switch name {
+		case "CurrentSessionNum":
+			s.Active, _ = strconv.Atoi(val)
+		case "MaxSessionNum":
+			s.Max, _ = strconv.Atoi(val)
+		case "TCPSessionNum":
+			s.Protocols["tcp"], _ = strconv.Atoi(val)
+		case "UDPSessionNum":
+			s.Protocols["udp"], _ = strconv.Atoi(val)
+		case "ICMPSessionNum":
+			s.Protocols["icmp"], _ = strconv.Atoi(val)
+		}
+	}
+	return &s
+}
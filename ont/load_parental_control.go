@@ -0,0 +1,95 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ParentalControlRule struct {
+	InstID     string
+	Name       string
+	MACAddress string
+	Enable     int
+	Days       string
+	StartTime  string
+	EndTime    string
+}
+
+type parentalControlResponse struct {
+	XMLName         xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM    string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE     string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR      string   `xml:"IF_ERRORSTR"`
+	IFERRORID       string   `xml:"IF_ERRORID"`
+	OBJPARENTCTRLID struct {
+		Instances []parentalControlInstance `xml:"Instance"`
+	} `xml:"OBJ_PARENTCTRL_ID"`
+}
+
+type parentalControlInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadParentalControl() ([]ParentalControlRule, error) {
+	// Trigger the menu to load the parental control schedules
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=parentalCtrl&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the parental control schedules
+	url := s.Endpoint + "/?_type=menuData&_tag=sec_parentctrl_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result parentalControlResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r parentalControlResponse) Convert() []ParentalControlRule {
+	var rules []ParentalControlRule
+	for _, inst := range r.OBJPARENTCTRLID.Instances {
+		rule := ParentalControlRule{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				rule.InstID = val
+			case "Name":
+				rule.Name = val
+			case "MACAddress":
+				rule.MACAddress = val
+			case "Enable":
+				rule.Enable, _ = strconv.Atoi(val)
+			case "Days":
+				rule.Days = val
+			case "StartTime":
+				rule.StartTime = val
+			case "EndTime":
+				rule.EndTime = val
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,85 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type PonStatistics struct {
+	FECCorrected     int
+	FECUncorrectable int
+	BIPErrors        int
+	HECErrors        int
+	DroppedGEMFrames int
+}
+
+type ponStatisticsResponse struct {
+	XMLName           xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM      string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE       string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR        string   `xml:"IF_ERRORSTR"`
+	IFERRORID         string   `xml:"IF_ERRORID"`
+	OBJPONSTATISTICID struct {
+		Instance ponStatisticsInstance `xml:"Instance"`
+	} `xml:"OBJ_PON_STATISTIC_ID"`
+}
+
+type ponStatisticsInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadPonStatistics() (*PonStatistics, error) {
+	// Trigger the menu to load the PON statistics
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ponInfo&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the PON statistics
+	url := s.Endpoint + "/?_type=menuData&_tag=pon_status_statistic_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result ponStatisticsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r ponStatisticsResponse) Convert() *PonStatistics {
+	s := PonStatistics{}
+	for i, name := range r.OBJPONSTATISTICID.Instance.ParaName {
+		if i >= len(r.OBJPONSTATISTICID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJPONSTATISTICID.Instance.ParaValue[i]
+		switch name {
+		case "FECCorrected":
+			s.FECCorrected, _ = strconv.Atoi(val)
+		case "FECUncorrectable":
+			s.FECUncorrectable, _ = strconv.Atoi(val)
+		case "BIPErrors":
+			s.BIPErrors, _ = strconv.Atoi(val)
+		case "HECErrors":
+			s.HECErrors, _ = strconv.Atoi(val)
+		case "DropGemFrames":
+			s.DroppedGEMFrames, _ = strconv.Atoi(val)
+		}
+	}
+	return &s
+}
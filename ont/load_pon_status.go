@@ -0,0 +1,111 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PonStatus struct {
+	// OnuState is the ITU-T G.984.3 activation state, normalised to "O1".."O7".
+	OnuState           string
+	LoidAuthStatus     string
+	PasswordAuthStatus string
+	LinkStatus         string
+	LOID               string
+}
+
+type ponStatusResponse struct {
+	XMLName          xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM     string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE      string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR       string   `xml:"IF_ERRORSTR"`
+	IFERRORID        string   `xml:"IF_ERRORID"`
+	OBJPONGPONINFOID struct {
+		Instance ponStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_PON_GPONINFO_ID"`
+	OBJPONAUTHID struct {
+		Instance ponStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_PON_AUTH_ID"`
+}
+
+type ponStatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadPonStatus() (*PonStatus, error) {
+	// Trigger the menu to load the PON status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ponInfo&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the PON status
+	url := s.Endpoint + "/?_type=menuData&_tag=pon_status_link_info_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result ponStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r ponStatusResponse) Convert() *PonStatus {
+	s := PonStatus{}
+	for i, name := range r.OBJPONGPONINFOID.Instance.ParaName {
+		if i >= len(r.OBJPONGPONINFOID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJPONGPONINFOID.Instance.ParaValue[i]
+		switch name {
+		case "RegStatus":
+			s.OnuState = normalizeOnuState(val)
+		case "LinkStatus":
+			s.LinkStatus = val
+		}
+	}
+
+	for i, name := range r.OBJPONAUTHID.Instance.ParaName {
+		if i >= len(r.OBJPONAUTHID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJPONAUTHID.Instance.ParaValue[i]
+		switch name {
+		case "LOID":
+			s.LOID = val
+		case "LoidAuthStatus":
+			s.LoidAuthStatus = val
+		case "PwdAuthStatus":
+			s.PasswordAuthStatus = val
+		}
+	}
+	return &s
+}
+
+// normalizeOnuState accepts both "5" and "O5" as reported by different firmware builds.
+func normalizeOnuState(val string) string {
+	val = strings.ToUpper(strings.TrimSpace(val))
+	if val == "" {
+		return ""
+	}
+	if !strings.HasPrefix(val, "O") {
+		val = "O" + val
+	}
+	return val
+}
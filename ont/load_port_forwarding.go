@@ -0,0 +1,104 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type PortForwardRule struct {
+	InstID            string
+	Name              string
+	Enable            int
+	Protocol          string
+	WANCName          string
+	ExternalPortStart int
+	ExternalPortEnd   int
+	InternalClient    string
+	InternalPortStart int
+	InternalPortEnd   int
+}
+
+type portForwardingResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJFWPMID    struct {
+		Instances []portForwardingInstance `xml:"Instance"`
+	} `xml:"OBJ_FWPM_ID"`
+}
+
+type portForwardingInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadPortForwarding() ([]PortForwardRule, error) {
+	// Trigger the menu to load the port forwarding rules
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=portForwarding&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the port forwarding rules
+	url := s.Endpoint + "/?_type=menuData&_tag=Internet_PortForwarding_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result portForwardingResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r portForwardingResponse) Convert() []PortForwardRule {
+	var rules []PortForwardRule
+	for _, inst := range r.OBJFWPMID.Instances {
+		rule := PortForwardRule{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				rule.InstID = val
+			case "Name":
+				rule.Name = val
+			case "Enable":
+				rule.Enable, _ = strconv.Atoi(val)
+			case "Protocol":
+				rule.Protocol = val
+			case "WANCName":
+				rule.WANCName = val
+			case "MinExtPort":
+				rule.ExternalPortStart, _ = strconv.Atoi(val)
+			case "MaxExtPort":
+				rule.ExternalPortEnd, _ = strconv.Atoi(val)
+			case "InternalHost":
+				rule.InternalClient = val
+			case "MinIntPort":
+				rule.InternalPortStart, _ = strconv.Atoi(val)
+			case "MaxIntPort":
+				rule.InternalPortEnd, _ = strconv.Atoi(val)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
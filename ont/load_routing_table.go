@@ -0,0 +1,137 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Route struct {
+	// Family is either "ipv4" or "ipv6"
+	Family      string
+	Destination string
+	Mask        string
+	Gateway     string
+	Interface   string
+	Metric      int
+}
+
+// IsDefault reports whether the route is the IPv4 or IPv6 default route.
+func (r Route) IsDefault() bool {
+	switch r.Family {
+	case "ipv4":
+		return r.Destination == "0.0.0.0" && (r.Mask == "0.0.0.0" || r.Mask == "")
+	case "ipv6":
+		return r.Destination == "::" || r.Destination == "::/0"
+	}
+	return false
+}
+
+type routingTableResponse struct {
+	XMLName         xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM    string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE     string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR      string   `xml:"IF_ERRORSTR"`
+	IFERRORID       string   `xml:"IF_ERRORID"`
+	OBJROUTETABLEID struct {
+		Instances []routingTableInstance `xml:"Instance"`
+	} `xml:"OBJ_ROUTE_TABLE_ID"`
+}
+
+type routingTableInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+// routingTablePages are the routing table pages of each address family.
+var routingTablePages = []struct {
+	Tag    string
+	Family string
+}{
+	{Tag: "status_route_table_lua.lua", Family: "ipv4"},
+	{Tag: "status_route6_table_lua.lua", Family: "ipv6"},
+}
+
+// LoadRoutingTable loads the IPv4 and IPv6 routes. When only one of the pages
+// fails, the routes of the other are returned with a *PartialError.
+func (s *Session) LoadRoutingTable() ([]Route, error) {
+	// Trigger the menu to load the routing tables
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=routeTable&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	var routes []Route
+	var partialErr *PartialError
+	failed := 0
+	for _, page := range routingTablePages {
+		pageRoutes, err := s.loadRoutingTablePage(page.Tag, page.Family)
+		if err != nil {
+			partialErr = &PartialError{Page: page.Family + " routing table", Err: err}
+			failed++
+			continue
+		}
+		routes = append(routes, pageRoutes...)
+	}
+
+	// Only fail when every page failed, IPv4 only firmware or configurations
+	// have no IPv6 routing table
+	if failed == len(routingTablePages) {
+		return nil, partialErr.Err
+	}
+	if partialErr != nil {
+		return routes, partialErr
+	}
+	return routes, nil
+}
+
+func (s *Session) loadRoutingTablePage(tag, family string) ([]Route, error) {
+	url := s.Endpoint + "/?_type=menuData&_tag=" + tag + "&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result routingTableResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(family), nil
+}
+
+func (r routingTableResponse) Convert(family string) []Route {
+	var routes []Route
+	for _, inst := range r.OBJROUTETABLEID.Instances {
+		route := Route{Family: family}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "DestIPAddress":
+				route.Destination = val
+			case "DestSubnetMask", "PrefixLength":
+				route.Mask = val
+			case "GatewayIPAddress":
+				route.Gateway = val
+			case "Interface":
+				route.Interface = val
+			case "ForwardingMetric":
+				route.Metric, _ = strconv.Atoi(val)
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
@@ -0,0 +1,92 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ServiceControlRule struct {
+	InstID       string
+	Service      string
+	Interface    string
+	Enable       int
+	SourceIPFrom string
+	SourceIPTo   string
+}
+
+type serviceControlResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJSCACLID   struct {
+		Instances []serviceControlInstance `xml:"Instance"`
+	} `xml:"OBJ_SC_ACL_ID"`
+}
+
+type serviceControlInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadServiceControl() ([]ServiceControlRule, error) {
+	// Trigger the menu to load the service access control rules
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=serviceCtrl&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the service access control rules
+	url := s.Endpoint + "/?_type=menuData&_tag=sec_servicectrl_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result serviceControlResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r serviceControlResponse) Convert() []ServiceControlRule {
+	var rules []ServiceControlRule
+	for _, inst := range r.OBJSCACLID.Instances {
+		rule := ServiceControlRule{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				rule.InstID = val
+			case "ServiceList":
+				rule.Service = val
+			case "Ingress":
+				rule.Interface = val
+			case "Enable":
+				rule.Enable, _ = strconv.Atoi(val)
+			case "MinSrcIp":
+				rule.SourceIPFrom = val
+			case "MaxSrcIp":
+				rule.SourceIPTo = val
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,167 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LogEntry struct {
+	Time    time.Time
+	Level   string
+	Module  string
+	Message string
+}
+
+func (e LogEntry) equal(o LogEntry) bool {
+	return e.Time.Equal(o.Time) && e.Level == o.Level && e.Module == o.Module && e.Message == o.Message
+}
+
+type systemLogResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJSYSLOGID  struct {
+		Instance systemLogInstance `xml:"Instance"`
+	} `xml:"OBJ_SYSLOG_ID"`
+}
+
+type systemLogInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+// LoadSystemLog returns the entries of the ONT system log, oldest first.
+func (s *Session) LoadSystemLog() ([]LogEntry, error) {
+	// Trigger the menu to load the system log
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=sysLog&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the system log
+	url := s.Endpoint + "/?_type=menuData&_tag=mgr_syslog_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result systemLogResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r systemLogResponse) Convert() []LogEntry {
+	for i, name := range r.OBJSYSLOGID.Instance.ParaName {
+		if i < len(r.OBJSYSLOGID.Instance.ParaValue) && name == "Content" {
+			return parseSystemLog(r.OBJSYSLOGID.Instance.ParaValue[i])
+		}
+	}
+	return nil
+}
+
+// logLinePattern matches lines such as
+//
+//	2024-05-01 12:00:00 [Error] [PPPoE] PPPoE connection down
+//	May  1 12:00:00 [Notice] [WEB] user login from 192.168.1.2
+var logLinePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}|[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})\s+\[([^\]]*)\]\s*(?:\[([^\]]*)\]\s*)?(.*)$`)
+
+func parseSystemLog(content string) []LogEntry {
+	var entries []LogEntry
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		match := logLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			// Continuation of a multi-line message
+			if len(entries) > 0 {
+				entries[len(entries)-1].Message += "\n" + strings.TrimSpace(line)
+			} else {
+				entries = append(entries, LogEntry{Message: strings.TrimSpace(line)})
+			}
+			continue
+		}
+
+		entries = append(entries, LogEntry{
+			Time:    parseLogTime(match[1]),
+			Level:   match[2],
+			Module:  match[3],
+			Message: strings.TrimSpace(match[4]),
+		})
+	}
+
+	// The web UI lists the newest entry first
+	if len(entries) > 1 && entries[0].Time.After(entries[len(entries)-1].Time) {
+		slices.Reverse(entries)
+	}
+	return entries
+}
+
+func parseLogTime(val string) time.Time {
+	if t := parseDeviceTime(val); !t.IsZero() {
+		return t
+	}
+
+	// Syslog style timestamps have no year
+	t, err := time.ParseInLocation(time.Stamp, val, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	now := time.Now()
+	t = t.AddDate(now.Year(), 0, 0)
+	if t.After(now.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
+
+// LogCursor remembers the last system log entry that has been seen.
+type LogCursor struct {
+	Last *LogEntry
+}
+
+// Next returns the entries following the last seen entry and advances the
+// cursor. When the last seen entry is no longer in the log, because the log
+// was cleared or the ONT rebooted, all entries are returned.
+func (c *LogCursor) Next(entries []LogEntry) []LogEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var next []LogEntry
+	if c.Last == nil {
+		next = entries
+	} else {
+		start := 0
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].equal(*c.Last) {
+				start = i + 1
+				break
+			}
+		}
+		next = entries[start:]
+	}
+
+	last := entries[len(entries)-1]
+	c.Last = &last
+	return next
+}
@@ -0,0 +1,100 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+	_ "time/tzdata"
+)
+
+type TimeStatus struct {
+	Enable     int
+	NTPServers []string
+	Status     string
+	// CurrentTime is the zero time when TimeZone isn't a known IANA zone, as
+	// the ONT reports its local time without an offset
+	CurrentTime time.Time
+	TimeZone    string
+}
+
+// Synchronized reports whether the NTP client has synchronised the clock.
+func (s TimeStatus) Synchronized() bool {
+	return s.Status == "Synchronized"
+}
+
+type timeStatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJSNTPID    struct {
+		Instance timeStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_SNTP_ID"`
+}
+
+type timeStatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadTimeStatus() (*TimeStatus, error) {
+	// Trigger the menu to load the time settings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=sntp&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the time settings
+	url := s.Endpoint + "/?_type=menuData&_tag=mgr_sntp_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result timeStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r timeStatusResponse) Convert() *TimeStatus {
+	s := TimeStatus{}
+	var currentTime string
+	for i, name := range r.OBJSNTPID.Instance.ParaName {
+		if i >= len(r.OBJSNTPID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJSNTPID.Instance.ParaValue[i]
+		switch name {
+		case "Enable":
+			s.Enable, _ = strconv.Atoi(val)
+		case "NTPServer1", "NTPServer2", "NTPServer3", "NTPServer4", "NTPServer5":
+			if val != "" {
+				s.NTPServers = append(s.NTPServers, val)
+			}
+		case "Status":
+			s.Status = val
+		case "CurrentLocalTime":
+			currentTime = val
+		case "LocalTimeZoneName":
+			s.TimeZone = val
+		}
+	}
+
+	if loc, err := time.LoadLocation(s.TimeZone); err == nil && s.TimeZone != "" {
+		s.CurrentTime = parseDeviceTimeIn(currentTime, loc)
+	}
+	return &s
+}
@@ -0,0 +1,94 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type TR069Status struct {
+	ACSURL                      string
+	PeriodicInformEnable        int
+	PeriodicInformInterval      int
+	LastInformTime              time.Time
+	LastInformResult            string
+	LastConnectionRequestTime   time.Time
+	LastConnectionRequestResult string
+	ConnectionRequestEnabled    int
+}
+
+type tr069StatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJTR069ID   struct {
+		Instance tr069StatusInstance `xml:"Instance"`
+	} `xml:"OBJ_TR069_ID"`
+}
+
+type tr069StatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadTR069Status() (*TR069Status, error) {
+	// Trigger the menu to load the remote management status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=tr069Status&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the remote management status
+	url := s.Endpoint + "/?_type=menuData&_tag=mgr_tr069_status_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result tr069StatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r tr069StatusResponse) Convert() *TR069Status {
+	s := TR069Status{}
+	for i, name := range r.OBJTR069ID.Instance.ParaName {
+		if i >= len(r.OBJTR069ID.Instance.ParaValue) {
+			continue
+		}
+		val := r.OBJTR069ID.Instance.ParaValue[i]
+		switch name {
+		case "URL":
+			s.ACSURL = val
+		case "PeriodicInformEnable":
+			s.PeriodicInformEnable, _ = strconv.Atoi(val)
+		case "PeriodicInformInterval":
+			s.PeriodicInformInterval, _ = strconv.Atoi(val)
+		case "LastInformTime":
+			s.LastInformTime = parseDeviceTime(val)
+		case "LastInformStatus":
+			s.LastInformResult = val
+		case "LastConnReqTime":
+			s.LastConnectionRequestTime = parseDeviceTime(val)
+		case "LastConnReqStatus":
+			s.LastConnectionRequestResult = val
+		case "ConnReqEnable":
+			s.ConnectionRequestEnabled, _ = strconv.Atoi(val)
+		}
+	}
+	return &s
+}
@@ -0,0 +1,98 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type UPnPPortMapping struct {
+	Enable         int
+	RemoteHost     string
+	ExternalPort   int
+	Protocol       string
+	InternalClient string
+	InternalPort   int
+	Description    string
+	LeaseDuration  int
+}
+
+type upnpMappingsResponse struct {
+	XMLName          xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM     string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE      string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR       string   `xml:"IF_ERRORSTR"`
+	IFERRORID        string   `xml:"IF_ERRORID"`
+	OBJUPNPPORTMAPID struct {
+		Instances []upnpMappingInstance `xml:"Instance"`
+	} `xml:"OBJ_UPNP_PORTMAP_ID"`
+}
+
+type upnpMappingInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadUPnPPortMappings() ([]UPnPPortMapping, error) {
+	// Trigger the menu to load the UPnP port mappings
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=upnp&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the UPnP port mappings
+	url := s.Endpoint + "/?_type=menuData&_tag=app_upnp_portmap_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result upnpMappingsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r upnpMappingsResponse) Convert() []UPnPPortMapping {
+	var mappings []UPnPPortMapping
+	for _, inst := range r.OBJUPNPPORTMAPID.Instances {
+		mapping := UPnPPortMapping{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "PortMappingEnabled":
+				mapping.Enable, _ = strconv.Atoi(val)
+			case "RemoteHost":
+				mapping.RemoteHost = val
+			case "ExternalPort":
+				mapping.ExternalPort, _ = strconv.Atoi(val)
+			case "PortMappingProtocol":
+				mapping.Protocol = val
+			case "InternalClient":
+				mapping.InternalClient = val
+			case "InternalPort":
+				mapping.InternalPort, _ = strconv.Atoi(val)
+			case "PortMappingDescription":
+				mapping.Description = val
+			case "PortMappingLeaseDuration":
+				mapping.LeaseDuration, _ = strconv.Atoi(val)
+			}
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
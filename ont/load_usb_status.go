@@ -0,0 +1,142 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type UsbDevice struct {
+	InstID       string
+	Port         string
+	Type         string
+	Manufacturer string
+	Product      string
+	SerialNumber string
+}
+
+type UsbPartition struct {
+	InstID     string
+	Device     string
+	Name       string
+	FileSystem string
+	MountPoint string
+	// Sizes are reported by the ONT in KiB and converted to bytes
+	TotalBytes int
+	FreeBytes  int
+}
+
+type UsbStatus struct {
+	Devices    []UsbDevice
+	Partitions []UsbPartition
+}
+
+type usbStatusResponse struct {
+	XMLName      xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE  string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
+	IFERRORID    string   `xml:"IF_ERRORID"`
+	OBJUSBDEVID  struct {
+		Instances []usbStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_USBDEV_ID"`
+	OBJUSBSTORAGEID struct {
+		Instances []usbStatusInstance `xml:"Instance"`
+	} `xml:"OBJ_USBSTORAGE_ID"`
+}
+
+type usbStatusInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadUsbStatus() (*UsbStatus, error) {
+	// Trigger the menu to load the USB status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=usbStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the USB status
+	url := s.Endpoint + "/?_type=menuData&_tag=status_usb_info_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result usbStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r usbStatusResponse) Convert() *UsbStatus {
+	status := &UsbStatus{}
+
+	for _, inst := range r.OBJUSBDEVID.Instances {
+		device := UsbDevice{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				device.InstID = val
+			case "PortName":
+				device.Port = val
+			case "DevType":
+				device.Type = val
+			case "Manufacturer":
+				device.Manufacturer = val
+			case "Product":
+				device.Product = val
+			case "SerialNumber":
+				device.SerialNumber = val
+			}
+		}
+		status.Devices = append(status.Devices, device)
+	}
+
+	for _, inst := range r.OBJUSBSTORAGEID.Instances {
+		partition := UsbPartition{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				partition.InstID = val
+			case "DevName":
+				partition.Device = val
+			case "PartitionName":
+				partition.Name = val
+			case "FileSystem":
+				partition.FileSystem = val
+			case "MountPath":
+				partition.MountPoint = val
+			case "TotalSize":
+				total, _ := strconv.Atoi(val)
+				partition.TotalBytes = total * 1024
+			case "FreeSize":
+				free, _ := strconv.Atoi(val)
+				partition.FreeBytes = free * 1024
+			}
+		}
+		status.Partitions = append(status.Partitions, partition)
+	}
+
+	return status
+}
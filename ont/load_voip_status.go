@@ -0,0 +1,104 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type VoIPLine struct {
+	InstID         string
+	Line           string
+	Number         string
+	RegisterStatus string
+	Registrar      string
+	HookStatus     string
+	IncomingCalls  int
+	OutgoingCalls  int
+	FailedCalls    int
+}
+
+type voipStatusResponse struct {
+	XMLName             xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM        string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE         string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR          string   `xml:"IF_ERRORSTR"`
+	IFERRORID           string   `xml:"IF_ERRORID"`
+	OBJVOIPLINESTATUSID struct {
+		Instances []voipLineInstance `xml:"Instance"`
+	} `xml:"OBJ_VOIP_LINESTATUS_ID"`
+}
+
+type voipLineInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadVoIPStatus() ([]VoIPLine, error) {
+	// Trigger the menu to load the VoIP status
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=voipStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the VoIP status
+	url := s.Endpoint + "/?_type=menuData&_tag=voip_voipstatus_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result voipStatusResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r voipStatusResponse) Convert() []VoIPLine {
+	var lines []VoIPLine
+	for _, inst := range r.OBJVOIPLINESTATUSID.Instances {
+		line := VoIPLine{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				line.InstID = val
+			case "LineNum":
+				line.Line = val
+			case "DirectoryNumber":
+				line.Number = val
+			case "RegisterStatus":
+				line.RegisterStatus = val
+			case "Registrar":
+				line.Registrar = val
+			case "HookStatus":
+				line.HookStatus = val
+			case "IncomingCallsReceived":
+				line.IncomingCalls, _ = strconv.Atoi(val)
+			case "OutgoingCallsAttempted":
+				line.OutgoingCalls, _ = strconv.Atoi(val)
+			case "CallsFailed":
+				line.FailedCalls, _ = strconv.Atoi(val)
+			}
+		}
+		if line.Line == "" {
+			line.Line = line.InstID
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
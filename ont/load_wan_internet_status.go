@@ -55,7 +55,7 @@ type wanInternetStatusResponse struct {
 	IFERRORSTR   string   `xml:"IF_ERRORSTR"`
 	IFERRORID    string   `xml:"IF_ERRORID"`
 	IDWANCONFIG  struct {
-		Instance wanInternetStatusInstance `xml:"Instance"`
+		Instances []wanInternetStatusInstance `xml:"Instance"`
 	} `xml:"ID_WAN_COMFIG"`
 }
 
@@ -64,14 +64,50 @@ type wanInternetStatusInstance struct {
 	ParaValue []string `xml:"ParaValue"`
 }
 
-func (s *Session) LoadWanInternetStatus() (*WanInternetStatus, error) {
+// wanPageTypes are the uplink/page type combinations of the WAN status page,
+// one for Ethernet uplink connections and one for PON uplink connections.
+var wanPageTypes = []struct {
+	TypeUplink int
+	PageType   int
+}{
+	{TypeUplink: 1, PageType: 1},
+	{TypeUplink: 2, PageType: 1},
+}
+
+func (s *Session) LoadWanInternetStatus() ([]WanInternetStatus, error) {
 	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ethWanStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
 	if respMenu != nil {
 		io.Copy(io.Discard, respMenu.Body)
 		respMenu.Body.Close()
 	}
 
-	url := s.Endpoint + "/?_type=menuData&_tag=wan_internetstatus_lua.lua&TypeUplink=2&pageType=1&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	var statuses []WanInternetStatus
+	var lastErr error
+	seen := make(map[string]bool)
+	for _, page := range wanPageTypes {
+		pageStatuses, err := s.loadWanInternetStatusPage(page.TypeUplink, page.PageType)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, status := range pageStatuses {
+			if status.InstID != "" && seen[status.InstID] {
+				continue
+			}
+			seen[status.InstID] = true
+			statuses = append(statuses, status)
+		}
+	}
+
+	// Only fail when none of the uplink pages could be loaded
+	if len(statuses) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return statuses, nil
+}
+
+func (s *Session) loadWanInternetStatusPage(typeUplink, pageType int) ([]WanInternetStatus, error) {
+	url := s.Endpoint + "/?_type=menuData&_tag=wan_internetstatus_lua.lua&TypeUplink=" + strconv.Itoa(typeUplink) + "&pageType=" + strconv.Itoa(pageType) + "&_=" + strconv.FormatInt(time.Now().Unix(), 10)
 	resp, err := s.Get(url)
 
 	if err != nil {
@@ -93,13 +129,21 @@ func (s *Session) LoadWanInternetStatus() (*WanInternetStatus, error) {
 	return result.Convert(), nil
 }
 
-func (r wanInternetStatusResponse) Convert() *WanInternetStatus {
+func (r wanInternetStatusResponse) Convert() []WanInternetStatus {
+	var statuses []WanInternetStatus
+	for _, inst := range r.IDWANCONFIG.Instances {
+		statuses = append(statuses, inst.Convert())
+	}
+	return statuses
+}
+
+func (inst wanInternetStatusInstance) Convert() WanInternetStatus {
 	s := WanInternetStatus{}
-	for i, name := range r.IDWANCONFIG.Instance.ParaName {
-		if i >= len(r.IDWANCONFIG.Instance.ParaValue) {
+	for i, name := range inst.ParaName {
+		if i >= len(inst.ParaValue) {
 			continue
 		}
-		val := r.IDWANCONFIG.Instance.ParaValue[i]
+		val := inst.ParaValue[i]
 		switch name {
 		case "ConnTrigger":
 			s.ConnTrigger = val
@@ -177,5 +221,5 @@ func (r wanInternetStatusResponse) Convert() *WanInternetStatus {
 			s.ConnStatus = val
 		}
 	}
-	return &s
+	return s
 }
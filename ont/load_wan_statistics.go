@@ -0,0 +1,98 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type WanStatistics struct {
+	InstID          string
+	WANCName        string
+	BytesSent       int
+	BytesReceived   int
+	PacketsSent     int
+	PacketsReceived int
+	ErrorsSent      int
+	ErrorsReceived  int
+}
+
+type wanStatisticsResponse struct {
+	XMLName            xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM       string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE        string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR         string   `xml:"IF_ERRORSTR"`
+	IFERRORID          string   `xml:"IF_ERRORID"`
+	OBJWANSTATISTICSID struct {
+		Instances []wanStatisticsInstance `xml:"Instance"`
+	} `xml:"OBJ_WAN_STATISTICS_ID"`
+}
+
+type wanStatisticsInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+func (s *Session) LoadWanStatistics() ([]WanStatistics, error) {
+	// Trigger the menu to load the WAN statistics
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=ethWanStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the WAN statistics
+	url := s.Endpoint + "/?_type=menuData&_tag=wan_statistics_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result wanStatisticsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r wanStatisticsResponse) Convert() []WanStatistics {
+	var stats []WanStatistics
+	for _, inst := range r.OBJWANSTATISTICSID.Instances {
+		stat := WanStatistics{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "_InstID":
+				stat.InstID = val
+			case "WANCName":
+				stat.WANCName = val
+			case "BytesSent":
+				stat.BytesSent, _ = strconv.Atoi(val)
+			case "BytesReceived":
+				stat.BytesReceived, _ = strconv.Atoi(val)
+			case "PacketsSent":
+				stat.PacketsSent, _ = strconv.Atoi(val)
+			case "PacketsReceived":
+				stat.PacketsReceived, _ = strconv.Atoi(val)
+			case "ErrorsSent":
+				stat.ErrorsSent, _ = strconv.Atoi(val)
+			case "ErrorsReceived":
+				stat.ErrorsReceived, _ = strconv.Atoi(val)
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
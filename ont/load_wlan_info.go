@@ -19,6 +19,17 @@ type WlanAP struct {
 	Encryption         string
 	TotalBytesSent     string
 	TotalBytesReceived string
+
+	Isolation  int
+	Guest      int
+	Hidden     int
+	MaxClients int
+
+	// WPSEnable and WPSPinEnable are -1 when the firmware does not report
+	// the WPS state of the AP
+	WPSEnable    int
+	WPSMode      string
+	WPSPinEnable int
 }
 
 type wlanAPInstance struct {
@@ -43,6 +54,9 @@ type wlanAPsResponse struct {
 	OBJWLANSETTINGID struct {
 		Instances []wlanAPInstance `xml:"Instance"`
 	} `xml:"OBJ_WLANSETTING_ID"`
+	OBJWPSID struct {
+		Instances []wlanAPInstance `xml:"Instance"`
+	} `xml:"OBJ_WPS_ID"`
 }
 
 func (inst *wlanAPInstance) ToMap() map[string]string {
@@ -60,6 +74,32 @@ func (inst *wlanAPInstance) ToMap() map[string]string {
 }
 
 func (s *Session) LoadWlanInfo() ([]WlanAP, error) {
+	result, err := s.loadWlanStatus()
+	if err != nil {
+		return nil, err
+	}
+	return result.Convert(), nil
+}
+
+type WlanStatus struct {
+	APs    []WlanAP
+	Radios []WlanRadio
+}
+
+// LoadWlanStatus loads the APs and the radios they run on from a single
+// request to the WLAN status page.
+func (s *Session) LoadWlanStatus() (*WlanStatus, error) {
+	result, err := s.loadWlanStatus()
+	if err != nil {
+		return nil, err
+	}
+	return &WlanStatus{
+		APs:    result.Convert(),
+		Radios: result.ConvertRadios(),
+	}, nil
+}
+
+func (s *Session) loadWlanStatus() (*wlanAPsResponse, error) {
 	// Trigger the menu to load the WLAN APs	info
 	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=localNetStatus&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
 	if respMenu != nil {
@@ -86,7 +126,7 @@ func (s *Session) LoadWlanInfo() ([]WlanAP, error) {
 		return nil, errors.New(result.IFERRORSTR)
 	}
 
-	return result.Convert(), nil
+	return &result, nil
 }
 
 func (r *wlanAPsResponse) Convert() []WlanAP {
@@ -114,6 +154,10 @@ func (r *wlanAPsResponse) Convert() []WlanAP {
 		if enc := m["11iEncryptType"]; enc != "" {
 			ap.Encryption = enc
 		}
+		ap.Isolation, _ = strconv.Atoi(m["APIsolation"])
+		ap.Guest, _ = strconv.Atoi(m["GuestEnable"])
+		ap.Hidden, _ = strconv.Atoi(m["ESSIDHideEnable"])
+		ap.MaxClients, _ = strconv.Atoi(m["MaxUserNum"])
 		wlanViewName := m["WLANViewName"]
 
 		for _, drv := range r.OBJWLANCONFIGDRVID.Instances {
@@ -126,6 +170,21 @@ func (r *wlanAPsResponse) Convert() []WlanAP {
 				break
 			}
 		}
+		ap.WPSEnable, ap.WPSPinEnable = -1, -1
+		for _, wps := range r.OBJWPSID.Instances {
+			wpsMap := wps.ToMap()
+			if wpsMap["APInstID"] != ap.InstID {
+				continue
+			}
+			if val, err := strconv.Atoi(wpsMap["Enable"]); err == nil {
+				ap.WPSEnable = val
+			}
+			if val, err := strconv.Atoi(wpsMap["DevicePinEnable"]); err == nil {
+				ap.WPSPinEnable = val
+			}
+			ap.WPSMode = wpsMap["WPSMode"]
+			break
+		}
 		ap.Band = bandMap[wlanViewName]
 		aps = append(aps, ap)
 	}
@@ -0,0 +1,94 @@
+package ont
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type WlanNeighbor struct {
+	SSID     string
+	BSSID    string
+	Channel  int
+	Band     string
+	Signal   int
+	Security string
+}
+
+type wlanNeighborsResponse struct {
+	XMLName           xml.Name `xml:"ajax_response_xml_root"`
+	IFERRORPARAM      string   `xml:"IF_ERRORPARAM"`
+	IFERRORTYPE       string   `xml:"IF_ERRORTYPE"`
+	IFERRORSTR        string   `xml:"IF_ERRORSTR"`
+	IFERRORID         string   `xml:"IF_ERRORID"`
+	OBJWLANNEIGHBORID struct {
+		Instances []wlanNeighborInstance `xml:"Instance"`
+	} `xml:"OBJ_WLAN_NEIGHBOR_ID"`
+}
+
+type wlanNeighborInstance struct {
+	ParaName  []string `xml:"ParaName"`
+	ParaValue []string `xml:"ParaValue"`
+}
+
+// LoadWlanNeighbors runs a site survey on the ONT radios. Scanning takes the
+// radios off channel for a moment, so avoid calling it on every scrape.
+func (s *Session) LoadWlanNeighbors() ([]WlanNeighbor, error) {
+	// Trigger the menu to load the neighbour scan
+	respMenu, _ := s.Get(s.Endpoint + "/?_type=menuView&_tag=wlanNeighbor&Menu3Location=0&_" + strconv.FormatInt(time.Now().Unix(), 10))
+	if respMenu != nil {
+		io.Copy(io.Discard, respMenu.Body)
+		respMenu.Body.Close()
+	}
+
+	// Load the neighbour scan
+	url := s.Endpoint + "/?_type=menuData&_tag=wlan_neighbor_lua.lua&_=" + strconv.FormatInt(time.Now().Unix(), 10)
+	resp, err := s.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result wlanNeighborsResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.IFERRORSTR != "SUCC" {
+		return nil, errors.New(result.IFERRORSTR)
+	}
+	return result.Convert(), nil
+}
+
+func (r wlanNeighborsResponse) Convert() []WlanNeighbor {
+	var neighbors []WlanNeighbor
+	for _, inst := range r.OBJWLANNEIGHBORID.Instances {
+		neighbor := WlanNeighbor{}
+		for i, name := range inst.ParaName {
+			if i >= len(inst.ParaValue) {
+				continue
+			}
+			val := inst.ParaValue[i]
+			switch name {
+			case "SSID":
+				neighbor.SSID = val
+			case "BSSID":
+				neighbor.BSSID = val
+			case "Channel":
+				neighbor.Channel, _ = strconv.Atoi(val)
+			case "Band":
+				neighbor.Band = val
+			case "RSSI":
+				neighbor.Signal, _ = strconv.Atoi(val)
+			case "EncryptionMode":
+				neighbor.Security = val
+			}
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+	return neighbors
+}
@@ -0,0 +1,79 @@
+package ont
+
+import (
+	"strconv"
+	"strings"
+)
+
+type WlanRadio struct {
+	InstID         string
+	Band           string
+	Enable         int
+	Channel        int
+	AutoChannel    int
+	ChannelWidth   string
+	TxPower        int
+	Standard       string
+	BeaconInterval int
+
+	// ChannelUtilization and Interference are percentages, or -1 when the
+	// firmware does not report them
+	ChannelUtilization int
+	Interference       int
+}
+
+func (r *wlanAPsResponse) ConvertRadios() []WlanRadio {
+	var radios []WlanRadio
+	for _, inst := range r.OBJWLANSETTINGID.Instances {
+		m := inst.ToMap()
+		radio := WlanRadio{
+			InstID:             m["_InstID"],
+			Band:               m["Band"],
+			ChannelWidth:       m["BandWidth"],
+			Standard:           m["WirelessMode"],
+			ChannelUtilization: -1,
+			Interference:       -1,
+		}
+		radio.Enable, _ = strconv.Atoi(m["RadioStatus"])
+		radio.Channel, _ = strconv.Atoi(m["Channel"])
+		radio.AutoChannel, _ = strconv.Atoi(m["AutoChannelEnabled"])
+		radio.TxPower, _ = strconv.Atoi(strings.TrimSuffix(m["TxPower"], "%"))
+		radio.BeaconInterval, _ = strconv.Atoi(m["BeaconInterval"])
+
+		if val, ok := m["ChannelUtilization"]; ok && val != "" {
+			radio.ChannelUtilization, _ = strconv.Atoi(val)
+		}
+		if val, ok := m["Interference"]; ok && val != "" {
+			radio.Interference, _ = strconv.Atoi(val)
+		}
+
+		// The setting object reports the configured channel, which is 0 when
+		// auto channel is on; the driver object of any AP on this radio has
+		// the one actually in use
+		if channel := r.channelInUse(radio.InstID); channel > 0 {
+			radio.Channel = channel
+		}
+
+		radios = append(radios, radio)
+	}
+	return radios
+}
+
+func (r *wlanAPsResponse) channelInUse(wlanViewName string) int {
+	for _, inst := range r.OBJWLANAPID.Instances {
+		m := inst.ToMap()
+		if m["WLANViewName"] != wlanViewName {
+			continue
+		}
+		for _, drv := range r.OBJWLANCONFIGDRVID.Instances {
+			drvMap := drv.ToMap()
+			if drvMap["_InstID"] != m["_InstID"] {
+				continue
+			}
+			if channel, err := strconv.Atoi(drvMap["ChannelInUsed"]); err == nil && channel > 0 {
+				return channel
+			}
+		}
+	}
+	return 0
+}
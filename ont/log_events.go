@@ -0,0 +1,51 @@
+package ont
+
+import "regexp"
+
+// logEventPatterns classify known system log entries, checked in order
+// against "<module> <message>". Alarm abbreviations such as LOS and LOF are
+// matched case-sensitively so words like "los" in host names don't count.
+var logEventPatterns = []struct {
+	Type    string
+	Pattern *regexp.Regexp
+	// SkipClears ignores the entries logged when the condition clears, so
+	// each outage is counted once
+	SkipClears bool
+}{
+	{"dhcp_conflict", regexp.MustCompile(`(?i)(ip|address) conflict|dhcp.*(conflict|declin)`), false},
+	{"pon_los", regexp.MustCompile(`\bLOS\b|(?i:loss of signal)`), true},
+	{"pon_lof", regexp.MustCompile(`\bLOF\b|(?i:loss of frame)`), true},
+	{"pppoe_reconnect", regexp.MustCompile(`(?i)ppp(oe)?\b.*(down|disconnect|reconnect|terminat|timeout)`), true},
+	{"dhcp_wan_reconnect", regexp.MustCompile(`(?i)dhcp ?c(lient)?\b.*(lease (lost|expired)|release|reconnect|restart|renew fail)`), true},
+	{"web_login", regexp.MustCompile(`(?i)(web|http|user)\b.*log ?in\b.*(succe|from)|logged in`), false},
+	{"reboot", regexp.MustCompile(`(?i)reboot|system (start|boot)|power on`), false},
+}
+
+// logClearPattern matches entries logged when an alarm or outage clears, or
+// a connection comes back up.
+var logClearPattern = regexp.MustCompile(`(?i)\b(clear(ed)?|restored?|recover(ed|y)?|up)\b`)
+
+// LogEventTypes returns all event types ClassifyLogEntry can return.
+func LogEventTypes() []string {
+	types := make([]string, 0, len(logEventPatterns))
+	for _, p := range logEventPatterns {
+		types = append(types, p.Type)
+	}
+	return types
+}
+
+// ClassifyLogEntry returns the event type of a known system log entry, or an
+// empty string for entries that don't match any known event.
+func ClassifyLogEntry(entry LogEntry) string {
+	text := entry.Module + " " + entry.Message
+	for _, p := range logEventPatterns {
+		if !p.Pattern.MatchString(text) {
+			continue
+		}
+		if p.SkipClears && logClearPattern.MatchString(text) {
+			return ""
+		}
+		return p.Type
+	}
+	return ""
+}
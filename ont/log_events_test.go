@@ -0,0 +1,35 @@
+package ont
+
+import "testing"
+
+func TestClassifyLogEntry(t *testing.T) {
+	tests := []struct {
+		module  string
+		message string
+		want    string
+	}{
+		{"PON", "PON LOS alarm raised", "pon_los"},
+		{"PON", "PON LOS alarm cleared", ""},
+		{"PON", "Optical loss of signal detected", "pon_los"},
+		{"PON", "Loss of signal restored", ""},
+		{"PON", "LOF alarm raised", "pon_lof"},
+		{"PON", "LOF alarm cleared", ""},
+		{"DHCPS", "Lease 192.168.1.23 to los-angeles-pc", ""},
+		{"PPPOE", "PPPoE connection down, reason: LCP echo timeout", "pppoe_reconnect"},
+		{"PPPOE", "PPPoE reconnect succeeded, link up", ""},
+		{"PPP", "PPP session terminated by peer", "pppoe_reconnect"},
+		{"DHCPC", "DHCP client lease expired on WAN1", "dhcp_wan_reconnect"},
+		{"DHCPC", "DHCP client lease recovered on WAN1", ""},
+		{"DHCPS", "IP conflict detected for 192.168.1.40", "dhcp_conflict"},
+		{"WEB", "User admin login succeeded from 192.168.1.10", "web_login"},
+		{"SYSTEM", "System reboot by user", "reboot"},
+		{"SYSTEM", "NTP time synchronized", ""},
+	}
+
+	for _, tt := range tests {
+		got := ClassifyLogEntry(LogEntry{Module: tt.module, Message: tt.message})
+		if got != tt.want {
+			t.Errorf("ClassifyLogEntry(%q, %q) = %q, want %q", tt.module, tt.message, got, tt.want)
+		}
+	}
+}
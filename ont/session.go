@@ -1,8 +1,15 @@
 package ont
 
-import "net/http"
+import (
+	"net/http"
+	"sync"
+)
 
 type Session struct {
 	*http.Client
 	Endpoint string
+
+	// The ONT keeps the currently opened menu as server side state, so
+	// callers sharing a session must hold the lock around their loads.
+	sync.Mutex
 }
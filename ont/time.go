@@ -0,0 +1,36 @@
+package ont
+
+import (
+	"strings"
+	"time"
+)
+
+var deviceTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// parseDeviceTime parses a timestamp reported by the ONT. Timestamps without
+// a zone are interpreted in the exporter's local time zone. Empty values and
+// the TR-069 "unknown time" (year 0001) return the zero time.
+func parseDeviceTime(val string) time.Time {
+	return parseDeviceTimeIn(val, time.Local)
+}
+
+// parseDeviceTimeIn is parseDeviceTime for timestamps without a zone that are
+// known to be in loc.
+func parseDeviceTimeIn(val string, loc *time.Location) time.Time {
+	val = strings.TrimSpace(val)
+	for _, layout := range deviceTimeLayouts {
+		t, err := time.ParseInLocation(layout, val, loc)
+		if err != nil {
+			continue
+		}
+		if t.Year() <= 1 {
+			return time.Time{}
+		}
+		return t
+	}
+	return time.Time{}
+}
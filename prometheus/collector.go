@@ -2,6 +2,7 @@ package prometheus
 
 import (
 	"cmp"
+	"errors"
 	"log"
 	"os"
 	"prometheus_F670L/ont"
@@ -13,16 +14,57 @@ import (
 
 // ONTCollector implements the prometheus.Collector interface
 type ONTCollector struct {
-	session *ont.Session
+	session  *ont.Session
+	counters *counterTracker
+	scanner  *wlanScanner
+	upnp     *upnpWatcher
+	events   *eventCounter
+
+	reservations *reservationTracker
+	partialPages map[string]bool
+	inventory    *inventoryCache
+	diag         *DiagProber
 }
 
-// NewONTCollector creates a new ONT metrics collector
-func NewONTCollector(session *ont.Session) *ONTCollector {
+// NewONTCollector creates a new ONT metrics collector. diag may be nil when
+// diagnostics are disabled.
+func NewONTCollector(session *ont.Session, diag *DiagProber) *ONTCollector {
 	return &ONTCollector{
-		session: session,
+		session:  session,
+		counters: newCounterTracker(),
+		scanner:  newWlanScanner(),
+		upnp:     newUPnPWatcher(),
+		events:   newEventCounter(),
+
+		reservations: newReservationTracker(),
+		partialPages: make(map[string]bool),
+		inventory:    newInventoryCache(),
+		diag:         diag,
 	}
 }
 
+// ObserveSystemLog counts the events in system log entries loaded elsewhere,
+// so the collector doesn't load the log a second time.
+func (c *ONTCollector) ObserveSystemLog(entries []ont.LogEntry) {
+	c.events.Observe(entries)
+}
+
+// partial logs a *ont.PartialError the first time its page fails, since
+// optional pages missing from the firmware would fail on every scrape, and
+// returns nil for it so the loaded data is still collected. Other errors are
+// returned unchanged.
+func (c *ONTCollector) partial(err error) error {
+	var partialErr *ont.PartialError
+	if !errors.As(err, &partialErr) {
+		return err
+	}
+	if !c.partialPages[partialErr.Page] {
+		c.partialPages[partialErr.Page] = true
+		log.Printf("Error %v, collecting the remaining data", partialErr)
+	}
+	return nil
+}
+
 func mapDuplex(val int) string {
 	switch val {
 	case 1:
@@ -47,6 +89,16 @@ func mapSpeed(val int) string {
 	}
 }
 
+func portRange(start, end int) string {
+	if end == 0 || end == start {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "-" + strconv.Itoa(end)
+}
+
+// onuStates are the GPON ONU activation states defined in ITU-T G.984.3
+var onuStates = []string{"O1", "O2", "O3", "O4", "O5", "O6", "O7"}
+
 // Describe implements prometheus.Collector
 func (c *ONTCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- deviceInfoDesc
@@ -62,7 +114,102 @@ func (c *ONTCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- lanClientStatusDesc
 	ch <- lanDHCPHostDesc
 	ch <- lanDHCPSettingsDesc
-
+	ch <- wanInternetStatusDesc
+	ch <- wanUpDesc
+	ch <- wanUptimeDesc
+	ch <- wanIPv6UpDesc
+	ch <- wanIPv6InfoDesc
+	ch <- ipv6PrefixInfoDesc
+	ch <- ipv6PrefixValidLifetimeDesc
+	ch <- ipv6PrefixPreferredLifetimeDesc
+	ch <- wanBytesDesc
+	ch <- wanPacketsDesc
+	ch <- wanErrorsDesc
+	ch <- voipLineRegisteredDesc
+	ch <- voipLineInfoDesc
+	ch <- voipLineOffHookDesc
+	ch <- voipCallsDesc
+	ch <- voipCallsFailedDesc
+	ch <- usbDeviceInfoDesc
+	ch <- usbStorageSizeDesc
+	ch <- usbStorageFreeDesc
+	ch <- wlanRadioInfoDesc
+	ch <- wlanRadioEnabledDesc
+	ch <- wlanRadioChannelDesc
+	ch <- wlanRadioAutoChannelDesc
+	ch <- wlanRadioTxPowerDesc
+	ch <- wlanRadioBeaconIntervalDesc
+	ch <- wlanRadioChannelUtilizationDesc
+	ch <- wlanRadioInterferenceDesc
+	ch <- wlanNeighborSignalDesc
+	ch <- wlanNeighborChannelDesc
+	ch <- meshAgentInfoDesc
+	ch <- meshBackhaulRateDesc
+	ch <- meshBackhaulRSSIDesc
+	ch <- meshClientAgentDesc
+	ch <- arpEntriesDesc
+	ch <- arpEntryInfoDesc
+	ch <- routeInfoDesc
+	ch <- defaultRouteDesc
+	ch <- natSessionsActiveDesc
+	ch <- natSessionsMaxDesc
+	ch <- natProtocolSessionsDesc
+	ch <- upnpPortMappingInfoDesc
+	ch <- upnpPortMappingLeaseDesc
+	ch <- upnpPortMappingsDesc
+	ch <- portForwardRuleDesc
+	ch <- dmzInfoDesc
+	ch <- firewallInfoDesc
+	ch <- firewallAntiAttackEnabledDesc
+	ch <- algEnabledDesc
+	ch <- serviceAccessDesc
+	ch <- tr069InfoDesc
+	ch <- tr069PeriodicInformIntervalDesc
+	ch <- tr069LastInformDesc
+	ch <- tr069LastConnectionRequestDesc
+	ch <- eventsDesc
+	ch <- ddnsInfoDesc
+	ch <- ddnsLastUpdateSuccessDesc
+	ch <- ddnsLastUpdateDesc
+	ch <- ddnsIPMatchesWANDesc
+	ch <- igmpInfoDesc
+	ch <- igmpGroupsDesc
+	ch <- igmpGroupInfoDesc
+	ch <- ntpSynchronizedDesc
+	ch <- ntpServerDesc
+	ch <- timeInfoDesc
+	ch <- clockSkewDesc
+	ch <- lanDHCPReservationDesc
+	ch <- lanDHCPHostReservedDesc
+	ch <- lanDHCPReservationLastSeenDesc
+	ch <- lanDHCPReservationStaleDesc
+	ch <- macFilterEnabledDesc
+	ch <- macFilterInfoDesc
+	ch <- parentalControlInfoDesc
+	ch <- wlanAPStatusDesc
+	ch <- wlanAPIsolationDesc
+	ch <- wlanAPGuestDesc
+	ch <- wlanAPHiddenDesc
+	ch <- wlanAPMaxClientsDesc
+	ch <- wlanAPWPSEnabledDesc
+	ch <- wlanAPWPSPinEnabledDesc
+	ch <- diagPingRTTDesc
+	ch <- diagPingLossDesc
+	ch <- diagTracerouteHopsDesc
+	ch <- diagTracerouteHopRTTDesc
+	ch <- lanLoopDetectionEnabledDesc
+	ch <- lanLoopDetectionRecoveryIntervalDesc
+	ch <- lanPortLoopDetectionEnabledDesc
+	ch <- lanPortLoopDetectedDesc
+	ch <- lanPortIsolatedDesc
+	ch <- ponOnuStateDesc
+	ch <- ponRegisteredDesc
+	ch <- ponStatusDesc
+	ch <- ponFECCorrectedDesc
+	ch <- ponFECUncorrectableDesc
+	ch <- ponBIPErrorsDesc
+	ch <- ponHECErrorsDesc
+	ch <- ponDroppedGEMFramesDesc
 }
 
 func sleepQuit(reaason string) {
@@ -77,6 +224,9 @@ func sleepQuit(reaason string) {
 
 // Collect implements prometheus.Collector
 func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
+	c.session.Lock()
+	defer c.session.Unlock()
+
 	// Collect Device Info
 	deviceInfo, err := c.session.LoadDeviceInfo()
 	if err != nil {
@@ -84,6 +234,7 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 		sleepQuit(err.Error())
 		return
 	}
+	c.counters.ObserveUptime(deviceInfo.Uptime)
 
 	ch <- prometheus.MustNewConstMetric(
 		deviceInfoDesc,
@@ -187,6 +338,100 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 		mapDuplex(duplexInt),
 	)
 
+	// PON Status
+	ponStatus, err := c.session.LoadPonStatus()
+	if err != nil {
+		log.Printf("Error loading PON status: %v", err)
+	} else {
+		for _, state := range onuStates {
+			value := 0.0
+			if state == ponStatus.OnuState {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(ponOnuStateDesc, prometheus.GaugeValue, value, state)
+		}
+
+		registered := 0.0
+		if ponStatus.OnuState == "O5" {
+			registered = 1
+		}
+		ch <- prometheus.MustNewConstMetric(ponRegisteredDesc, prometheus.GaugeValue, registered)
+
+		ch <- prometheus.MustNewConstMetric(
+			ponStatusDesc,
+			prometheus.GaugeValue,
+			1,
+			ponStatus.LinkStatus,
+			ponStatus.LoidAuthStatus,
+			ponStatus.PasswordAuthStatus,
+		)
+	}
+
+	// PON Statistics
+	ponStats, err := c.session.LoadPonStatistics()
+	if err != nil {
+		log.Printf("Error loading PON statistics: %v", err)
+	} else {
+		ponCounters := []struct {
+			desc  *prometheus.Desc
+			key   string
+			value int
+		}{
+			{ponFECCorrectedDesc, "pon_fec_corrected", ponStats.FECCorrected},
+			{ponFECUncorrectableDesc, "pon_fec_uncorrectable", ponStats.FECUncorrectable},
+			{ponBIPErrorsDesc, "pon_bip_errors", ponStats.BIPErrors},
+			{ponHECErrorsDesc, "pon_hec_errors", ponStats.HECErrors},
+			{ponDroppedGEMFramesDesc, "pon_gem_frames_dropped", ponStats.DroppedGEMFrames},
+		}
+		for _, m := range ponCounters {
+			ch <- prometheus.MustNewConstMetric(
+				m.desc,
+				prometheus.CounterValue,
+				c.counters.Value(m.key, m.value),
+			)
+		}
+	}
+
+	// Mesh Topology, collected before the WLAN clients so they can be labelled with their agent
+	clientAgents := make(map[string]string)
+	meshTopology, err := c.session.LoadMeshTopology()
+	if err != nil {
+		log.Printf("Error loading mesh topology: %v", err)
+	} else {
+		agentNames := make(map[string]string)
+		for _, agent := range meshTopology.Agents {
+			agentNames[normalizeMAC(agent.MACAddress)] = cmp.Or(agent.DeviceName, agent.MACAddress)
+			ch <- prometheus.MustNewConstMetric(
+				meshAgentInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				agent.InstID, agent.MACAddress, agent.DeviceName, agent.Role, agent.IPAddress, agent.ParentMAC, agent.BackhaulType,
+			)
+			// The controller itself has no backhaul
+			if agent.ParentMAC == "" {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				meshBackhaulRateDesc,
+				prometheus.GaugeValue,
+				float64(agent.BackhaulRate)*1e6,
+				agent.MACAddress, agent.DeviceName, agent.BackhaulType,
+			)
+			if agent.BackhaulRSSI != 0 {
+				ch <- prometheus.MustNewConstMetric(meshBackhaulRSSIDesc, prometheus.GaugeValue, float64(agent.BackhaulRSSI), agent.MACAddress, agent.DeviceName)
+			}
+		}
+		for _, client := range meshTopology.Clients {
+			clientAgents[normalizeMAC(client.MACAddress)] = agentNames[normalizeMAC(client.AgentMAC)]
+			ch <- prometheus.MustNewConstMetric(
+				meshClientAgentDesc,
+				prometheus.GaugeValue,
+				1,
+				client.MACAddress, client.AgentMAC, agentNames[normalizeMAC(client.AgentMAC)], client.Band,
+			)
+		}
+	}
+
 	// WLAN Info
 	wlanInfo, err := c.session.LoadWlanClientsInfo()
 	if err != nil {
@@ -194,6 +439,7 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 	} else {
 		apMap := make(map[string]string)
 		for _, client := range wlanInfo.Clients {
+			c.reservations.Seen(client.MACAddress)
 			essid := apMap[client.AliasName]
 			ch <- prometheus.MustNewConstMetric(
 				wlanClientStatusDesc,
@@ -214,6 +460,7 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 				client.CurrentMode,
 				strconv.Itoa(client.MCS),
 				client.BAND,
+				clientAgents[normalizeMAC(client.MACAddress)],
 			)
 		}
 	}
@@ -224,6 +471,7 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 		log.Printf("Error loading LAN clients: %v", err)
 	} else {
 		for _, client := range lanClients {
+			c.reservations.Seen(client.MACAddress)
 			ch <- prometheus.MustNewConstMetric(
 				lanClientStatusDesc,
 				prometheus.GaugeValue,
@@ -234,57 +482,221 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	// WAN Internet Status
-	wanStatus, err := c.session.LoadWanInternetStatus()
+	wanStatuses, err := c.session.LoadWanInternetStatus()
 	if err != nil {
 		log.Printf("Error loading WAN Internet status: %v", err)
+	} else {
+		for _, wanStatus := range wanStatuses {
+			ch <- prometheus.MustNewConstMetric(
+				wanInternetStatusDesc,
+				prometheus.GaugeValue,
+				1,
+				wanStatus.ConnTrigger,
+				strconv.Itoa(wanStatus.UpTime),
+				strconv.Itoa(wanStatus.IsNAT),
+				wanStatus.ConnError,
+				wanStatus.XdslMode,
+				wanStatus.WanType,
+				wanStatus.WANCName,
+				wanStatus.IpMode,
+				wanStatus.TransType,
+				wanStatus.PPPoeServiceName,
+				wanStatus.Mode,
+				strconv.Itoa(wanStatus.Uplink),
+				strconv.Itoa(wanStatus.PageType),
+				strconv.Itoa(wanStatus.VlanEnable),
+				wanStatus.StrServList,
+				wanStatus.ConnStatus6,
+				wanStatus.InstID,
+				strconv.Itoa(wanStatus.Enable),
+				strconv.Itoa(wanStatus.DSCP),
+				strconv.Itoa(wanStatus.Priority),
+				strconv.Itoa(wanStatus.VLANID),
+				wanStatus.SubnetMask,
+				wanStatus.AuthType,
+				strconv.Itoa(wanStatus.MTU),
+				wanStatus.DNS1,
+				wanStatus.DNS3,
+				wanStatus.GateWay,
+				wanStatus.WorkIFMac,
+				wanStatus.ServList,
+				wanStatus.LinkMode,
+				strconv.Itoa(wanStatus.IsDefGW),
+				wanStatus.IPAddress,
+				wanStatus.DNS2,
+				strconv.Itoa(wanStatus.EnablePassThrough),
+				wanStatus.ConnStatus,
+			)
+
+			up := 0.0
+			if wanStatus.ConnStatus == "Connected" {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(wanUpDesc, prometheus.GaugeValue, up, wanStatus.WANCName, wanStatus.InstID)
+			ch <- prometheus.MustNewConstMetric(wanUptimeDesc, prometheus.GaugeValue, float64(wanStatus.UpTime), wanStatus.WANCName, wanStatus.InstID)
+		}
+	}
+
+	// DDNS Status, compared against the WAN addresses loaded above
+	ddnsStatus, err := c.session.LoadDDNSStatus()
+	if err != nil {
+		log.Printf("Error loading DDNS status: %v", err)
 	} else {
 		ch <- prometheus.MustNewConstMetric(
-			wanInternetStatusDesc,
+			ddnsInfoDesc,
 			prometheus.GaugeValue,
-			1,
-			wanStatus.ConnTrigger,
-			strconv.Itoa(wanStatus.UpTime),
-			strconv.Itoa(wanStatus.IsNAT),
-			wanStatus.ConnError,
-			wanStatus.XdslMode,
-			wanStatus.WanType,
-			wanStatus.WANCName,
-			wanStatus.IpMode,
-			wanStatus.TransType,
-			wanStatus.PPPoeServiceName,
-			wanStatus.Mode,
-			strconv.Itoa(wanStatus.Uplink),
-			strconv.Itoa(wanStatus.PageType),
-			strconv.Itoa(wanStatus.VlanEnable),
-			wanStatus.StrServList,
-			wanStatus.ConnStatus6,
-			wanStatus.InstID,
-			strconv.Itoa(wanStatus.Enable),
-			strconv.Itoa(wanStatus.DSCP),
-			strconv.Itoa(wanStatus.Priority),
-			strconv.Itoa(wanStatus.VLANID),
-			wanStatus.SubnetMask,
-			wanStatus.AuthType,
-			strconv.Itoa(wanStatus.MTU),
-			wanStatus.DNS1,
-			wanStatus.DNS3,
-			wanStatus.GateWay,
-			wanStatus.WorkIFMac,
-			wanStatus.ServList,
-			wanStatus.LinkMode,
-			strconv.Itoa(wanStatus.IsDefGW),
-			wanStatus.IPAddress,
-			wanStatus.DNS2,
-			strconv.Itoa(wanStatus.EnablePassThrough),
-			wanStatus.ConnStatus,
+			float64(ddnsStatus.Enable),
+			ddnsStatus.Provider, ddnsStatus.Hostname, ddnsStatus.WANCName, ddnsStatus.RegisteredIP, ddnsStatus.Status,
 		)
+
+		if ddnsStatus.Enable == 1 {
+			updateSuccess := 0.0
+			if ddnsStatus.UpdateSucceeded() {
+				updateSuccess = 1
+			}
+			ch <- prometheus.MustNewConstMetric(ddnsLastUpdateSuccessDesc, prometheus.GaugeValue, updateSuccess, ddnsStatus.Hostname)
+			if !ddnsStatus.LastUpdate.IsZero() {
+				ch <- prometheus.MustNewConstMetric(ddnsLastUpdateDesc, prometheus.GaugeValue, float64(ddnsStatus.LastUpdate.Unix()), ddnsStatus.Hostname)
+			}
+
+			if len(wanStatuses) > 0 {
+				matches := 0.0
+				for _, wanStatus := range wanStatuses {
+					if wanStatus.IPAddress != "" && wanStatus.IPAddress == ddnsStatus.RegisteredIP {
+						matches = 1
+						break
+					}
+				}
+				ch <- prometheus.MustNewConstMetric(ddnsIPMatchesWANDesc, prometheus.GaugeValue, matches, ddnsStatus.Hostname)
+			}
+		}
 	}
 
-	wlanAPs, err := c.session.LoadWlanInfo()
+	// WAN Statistics
+	wanStats, err := c.session.LoadWanStatistics()
 	if err != nil {
-		log.Printf("Error loading WLAN info: %v", err)
+		log.Printf("Error loading WAN statistics: %v", err)
 	} else {
-		for _, ap := range wlanAPs {
+		for _, stat := range wanStats {
+			wanCounters := []struct {
+				desc  *prometheus.Desc
+				name  string
+				value int
+				dir   string
+			}{
+				{wanBytesDesc, "wan_bytes", stat.BytesReceived, "in"},
+				{wanBytesDesc, "wan_bytes", stat.BytesSent, "out"},
+				{wanPacketsDesc, "wan_packets", stat.PacketsReceived, "in"},
+				{wanPacketsDesc, "wan_packets", stat.PacketsSent, "out"},
+				{wanErrorsDesc, "wan_errors", stat.ErrorsReceived, "in"},
+				{wanErrorsDesc, "wan_errors", stat.ErrorsSent, "out"},
+			}
+			for _, m := range wanCounters {
+				ch <- prometheus.MustNewConstMetric(
+					m.desc,
+					prometheus.CounterValue,
+					c.counters.Value(m.name+"/"+m.dir+"/"+stat.InstID, m.value),
+					m.dir, stat.WANCName, stat.InstID,
+				)
+			}
+		}
+	}
+
+	// IPv6 Status
+	ipv6Status, err := c.session.LoadIPv6Status()
+	if err = c.partial(err); err != nil {
+		log.Printf("Error loading IPv6 status: %v", err)
+	} else {
+		for _, wan := range ipv6Status.WAN {
+			up := 0.0
+			if wan.ConnStatus6 == "Connected" {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(wanIPv6UpDesc, prometheus.GaugeValue, up, wan.WANCName, wan.InstID)
+			ch <- prometheus.MustNewConstMetric(
+				wanIPv6InfoDesc,
+				prometheus.GaugeValue,
+				1,
+				wan.WANCName, wan.InstID, wan.GlobalAddress, wan.Gateway, wan.DNS1, wan.DNS2,
+			)
+
+			if wan.DelegatedPrefix == "" {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				ipv6PrefixInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				"wan", wan.WANCName, wan.InstID, wan.DelegatedPrefix, "delegated", "", "",
+			)
+			ch <- prometheus.MustNewConstMetric(ipv6PrefixValidLifetimeDesc, prometheus.GaugeValue, float64(wan.PrefixValidLifetime), wan.WANCName, wan.InstID, wan.DelegatedPrefix)
+			ch <- prometheus.MustNewConstMetric(ipv6PrefixPreferredLifetimeDesc, prometheus.GaugeValue, float64(wan.PrefixPreferredLifetime), wan.WANCName, wan.InstID, wan.DelegatedPrefix)
+		}
+
+		if ipv6Status.LAN != nil && ipv6Status.LAN.Prefix != "" {
+			ch <- prometheus.MustNewConstMetric(
+				ipv6PrefixInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				"lan", "", "", ipv6Status.LAN.Prefix, ipv6Status.LAN.PrefixOrigin,
+				strconv.Itoa(ipv6Status.LAN.RAEnable), ipv6Status.LAN.DHCPv6Mode,
+			)
+		}
+	}
+
+	// VoIP Status
+	voipLines, err := c.session.LoadVoIPStatus()
+	if err != nil {
+		log.Printf("Error loading VoIP status: %v", err)
+	} else {
+		for _, line := range voipLines {
+			registered := 0.0
+			if line.RegisterStatus == "Up" || line.RegisterStatus == "Registered" {
+				registered = 1
+			}
+			offHook := 0.0
+			if line.HookStatus == "OffHook" {
+				offHook = 1
+			}
+			ch <- prometheus.MustNewConstMetric(voipLineRegisteredDesc, prometheus.GaugeValue, registered, line.Line)
+			ch <- prometheus.MustNewConstMetric(voipLineOffHookDesc, prometheus.GaugeValue, offHook, line.Line)
+			ch <- prometheus.MustNewConstMetric(
+				voipLineInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				line.Line, line.Number, line.Registrar, line.RegisterStatus, line.HookStatus,
+			)
+			ch <- prometheus.MustNewConstMetric(voipCallsDesc, prometheus.CounterValue, c.counters.Value("voip_calls/in/"+line.Line, line.IncomingCalls), line.Line, "in")
+			ch <- prometheus.MustNewConstMetric(voipCallsDesc, prometheus.CounterValue, c.counters.Value("voip_calls/out/"+line.Line, line.OutgoingCalls), line.Line, "out")
+			ch <- prometheus.MustNewConstMetric(voipCallsFailedDesc, prometheus.CounterValue, c.counters.Value("voip_calls_failed/"+line.Line, line.FailedCalls), line.Line)
+		}
+	}
+
+	// USB Status
+	usbStatus, err := c.session.LoadUsbStatus()
+	if err != nil {
+		log.Printf("Error loading USB status: %v", err)
+	} else {
+		for _, device := range usbStatus.Devices {
+			ch <- prometheus.MustNewConstMetric(
+				usbDeviceInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				device.InstID, device.Port, device.Type, device.Manufacturer, device.Product, device.SerialNumber,
+			)
+		}
+		for _, partition := range usbStatus.Partitions {
+			labels := []string{partition.Device, partition.Name, partition.MountPoint, partition.FileSystem}
+			ch <- prometheus.MustNewConstMetric(usbStorageSizeDesc, prometheus.GaugeValue, float64(partition.TotalBytes), labels...)
+			ch <- prometheus.MustNewConstMetric(usbStorageFreeDesc, prometheus.GaugeValue, float64(partition.FreeBytes), labels...)
+		}
+	}
+
+	wlanStatus, err := c.session.LoadWlanStatus()
+	if err != nil {
+		log.Printf("Error loading WLAN status: %v", err)
+	} else {
+		for _, ap := range wlanStatus.APs {
 			ch <- prometheus.MustNewConstMetric(
 				wlanAPStatusDesc,
 				prometheus.GaugeValue,
@@ -300,6 +712,245 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 				ap.TotalBytesSent,
 				ap.TotalBytesReceived,
 			)
+
+			apGauges := []struct {
+				desc  *prometheus.Desc
+				value int
+			}{
+				{wlanAPIsolationDesc, ap.Isolation},
+				{wlanAPGuestDesc, ap.Guest},
+				{wlanAPHiddenDesc, ap.Hidden},
+				{wlanAPMaxClientsDesc, ap.MaxClients},
+				{wlanAPWPSPinEnabledDesc, ap.WPSPinEnable},
+			}
+			for _, m := range apGauges {
+				// The WPS state is unknown when the firmware doesn't report it
+				if m.value < 0 {
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, float64(m.value), ap.InstID, ap.ESSID, ap.Band)
+			}
+			if ap.WPSEnable >= 0 {
+				ch <- prometheus.MustNewConstMetric(wlanAPWPSEnabledDesc, prometheus.GaugeValue, float64(ap.WPSEnable), ap.InstID, ap.ESSID, ap.Band, ap.WPSMode)
+			}
+		}
+
+		for _, radio := range wlanStatus.Radios {
+			ch <- prometheus.MustNewConstMetric(
+				wlanRadioInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				radio.InstID, radio.Band, radio.Standard, radio.ChannelWidth,
+			)
+
+			radioGauges := []struct {
+				desc  *prometheus.Desc
+				value int
+			}{
+				{wlanRadioEnabledDesc, radio.Enable},
+				{wlanRadioChannelDesc, radio.Channel},
+				{wlanRadioAutoChannelDesc, radio.AutoChannel},
+				{wlanRadioTxPowerDesc, radio.TxPower},
+				{wlanRadioBeaconIntervalDesc, radio.BeaconInterval},
+				{wlanRadioChannelUtilizationDesc, radio.ChannelUtilization},
+				{wlanRadioInterferenceDesc, radio.Interference},
+			}
+			for _, m := range radioGauges {
+				// Not every firmware reports utilisation and interference
+				if m.value < 0 {
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, float64(m.value), radio.InstID, radio.Band)
+			}
+		}
+	}
+
+	// WLAN neighbour scan, cached between scans
+	type neighborChannel struct {
+		channel int
+		band    string
+	}
+	neighborChannels := make(map[neighborChannel]int)
+	for _, neighbor := range c.scanner.Neighbors(c.session) {
+		ch <- prometheus.MustNewConstMetric(
+			wlanNeighborSignalDesc,
+			prometheus.GaugeValue,
+			float64(neighbor.Signal),
+			neighbor.SSID, neighbor.BSSID, strconv.Itoa(neighbor.Channel), neighbor.Band, neighbor.Security,
+		)
+		neighborChannels[neighborChannel{neighbor.Channel, neighbor.Band}]++
+	}
+	for key, count := range neighborChannels {
+		ch <- prometheus.MustNewConstMetric(wlanNeighborChannelDesc, prometheus.GaugeValue, float64(count), strconv.Itoa(key.channel), key.band)
+	}
+
+	arpEntries, err := c.session.LoadArpTable()
+	if err != nil {
+		log.Printf("Error loading ARP table: %v", err)
+	} else {
+		arpCounts := make(map[string]int)
+		for _, entry := range arpEntries {
+			c.reservations.Seen(entry.MACAddress)
+			arpCounts[entry.Interface]++
+			ch <- prometheus.MustNewConstMetric(
+				arpEntryInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				entry.IPAddress, entry.MACAddress, entry.Interface, entry.Type,
+			)
+		}
+		for iface, count := range arpCounts {
+			ch <- prometheus.MustNewConstMetric(arpEntriesDesc, prometheus.GaugeValue, float64(count), iface)
+		}
+	}
+
+	routes, err := c.session.LoadRoutingTable()
+	if err = c.partial(err); err != nil {
+		log.Printf("Error loading routing table: %v", err)
+	} else {
+		defaultRoutes := make(map[ont.Route]bool)
+		for _, route := range routes {
+			ch <- prometheus.MustNewConstMetric(
+				routeInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				route.Family, route.Destination, route.Mask, route.Gateway, route.Interface, strconv.Itoa(route.Metric),
+			)
+			// Several default routes may only differ in metric
+			defaultRoute := ont.Route{Family: route.Family, Gateway: route.Gateway, Interface: route.Interface}
+			if route.IsDefault() && !defaultRoutes[defaultRoute] {
+				defaultRoutes[defaultRoute] = true
+				ch <- prometheus.MustNewConstMetric(defaultRouteDesc, prometheus.GaugeValue, 1, route.Family, route.Gateway, route.Interface)
+			}
+		}
+	}
+
+	natSessions, err := c.session.LoadNatSessions()
+	if err != nil {
+		log.Printf("Error loading NAT sessions: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(natSessionsActiveDesc, prometheus.GaugeValue, float64(natSessions.Active))
+		if natSessions.Max > 0 {
+			ch <- prometheus.MustNewConstMetric(natSessionsMaxDesc, prometheus.GaugeValue, float64(natSessions.Max))
+		}
+		for protocol, count := range natSessions.Protocols {
+			ch <- prometheus.MustNewConstMetric(natProtocolSessionsDesc, prometheus.GaugeValue, float64(count), protocol)
+		}
+	}
+
+	upnpMappings, err := c.session.LoadUPnPPortMappings()
+	if err != nil {
+		log.Printf("Error loading UPnP port mappings: %v", err)
+	} else {
+		c.upnp.Observe(upnpMappings)
+		activeMappings := 0
+		for _, m := range upnpMappings {
+			if m.Enable == 1 {
+				activeMappings++
+			}
+			externalPort := strconv.Itoa(m.ExternalPort)
+			ch <- prometheus.MustNewConstMetric(
+				upnpPortMappingInfoDesc,
+				prometheus.GaugeValue,
+				float64(m.Enable),
+				m.Protocol, m.RemoteHost, externalPort, m.InternalClient, strconv.Itoa(m.InternalPort), m.Description,
+			)
+			ch <- prometheus.MustNewConstMetric(upnpPortMappingLeaseDesc, prometheus.GaugeValue, float64(m.LeaseDuration), m.Protocol, m.RemoteHost, externalPort)
+		}
+		ch <- prometheus.MustNewConstMetric(upnpPortMappingsDesc, prometheus.GaugeValue, float64(activeMappings))
+	}
+
+	// Configuration inventory, cached between loads
+	for _, m := range c.inventory.Metrics(c.collectInventory) {
+		ch <- m
+	}
+
+	// System log events, counted from the log loaded on its own interval
+	for eventType, count := range c.events.Counts(c.session) {
+		ch <- prometheus.MustNewConstMetric(eventsDesc, prometheus.CounterValue, float64(count), eventType)
+	}
+
+	igmpStatus, err := c.session.LoadIGMPStatus()
+	if err != nil {
+		log.Printf("Error loading IGMP status: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(
+			igmpInfoDesc,
+			prometheus.GaugeValue,
+			1,
+			strconv.Itoa(igmpStatus.SnoopingEnable), strconv.Itoa(igmpStatus.ProxyEnable), igmpStatus.UpstreamInterface,
+		)
+
+		igmpGroups := make(map[string]int)
+		for _, group := range igmpStatus.Groups {
+			igmpGroups[group.Interface]++
+			ch <- prometheus.MustNewConstMetric(
+				igmpGroupInfoDesc,
+				prometheus.GaugeValue,
+				float64(group.Members),
+				group.GroupAddress, group.SourceAddress, group.Interface, group.MemberPorts,
+			)
+		}
+		for iface, count := range igmpGroups {
+			ch <- prometheus.MustNewConstMetric(igmpGroupsDesc, prometheus.GaugeValue, float64(count), iface)
+		}
+	}
+
+	loopDetection, err := c.session.LoadLoopDetection()
+	if err != nil {
+		log.Printf("Error loading loopback detection: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(lanLoopDetectionEnabledDesc, prometheus.GaugeValue, float64(loopDetection.Enable))
+		ch <- prometheus.MustNewConstMetric(lanLoopDetectionRecoveryIntervalDesc, prometheus.GaugeValue, float64(loopDetection.RecoveryInterval))
+		for _, port := range loopDetection.Ports {
+			looped := 0.0
+			if port.Looped() {
+				looped = 1
+			}
+			ch <- prometheus.MustNewConstMetric(lanPortLoopDetectionEnabledDesc, prometheus.GaugeValue, float64(port.Enable), port.Port)
+			ch <- prometheus.MustNewConstMetric(lanPortLoopDetectedDesc, prometheus.GaugeValue, looped, port.Port)
+			ch <- prometheus.MustNewConstMetric(lanPortIsolatedDesc, prometheus.GaugeValue, float64(port.Isolated), port.Port)
+		}
+	}
+
+	timeStatus, err := c.session.LoadTimeStatus()
+	if err != nil {
+		log.Printf("Error loading time status: %v", err)
+	} else {
+		synchronized := 0.0
+		if timeStatus.Synchronized() {
+			synchronized = 1
+		}
+		ch <- prometheus.MustNewConstMetric(ntpSynchronizedDesc, prometheus.GaugeValue, synchronized)
+		ch <- prometheus.MustNewConstMetric(
+			timeInfoDesc,
+			prometheus.GaugeValue,
+			1,
+			strconv.Itoa(timeStatus.Enable), timeStatus.Status, timeStatus.TimeZone,
+		)
+		for i, server := range timeStatus.NTPServers {
+			ch <- prometheus.MustNewConstMetric(ntpServerDesc, prometheus.GaugeValue, 1, server, strconv.Itoa(i+1))
+		}
+		if !timeStatus.CurrentTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(clockSkewDesc, prometheus.GaugeValue, timeStatus.CurrentTime.Sub(time.Now()).Seconds())
+		}
+	}
+
+	// DHCP static reservations, loaded first so the DHCP hosts can be marked as reserved
+	reserved := make(map[string]bool)
+	lanDHCPBindings, err := c.session.LoadLanDHCPStaticBindings()
+	reservationsLoaded := err == nil
+	if err != nil {
+		log.Printf("Error loading LAN DHCP static bindings: %v", err)
+	} else {
+		for _, binding := range lanDHCPBindings {
+			reserved[normalizeMAC(binding.MACAddr)] = true
+			ch <- prometheus.MustNewConstMetric(
+				lanDHCPReservationDesc,
+				prometheus.GaugeValue,
+				1,
+				binding.InstID, binding.MACAddr, binding.IPAddr, binding.HostName,
+			)
 		}
 	}
 
@@ -308,6 +959,7 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 		log.Printf("Error loading LAN DHCP hosts: %v", err)
 	} else {
 		for _, host := range lanDHCPHosts {
+			c.reservations.Seen(host.MACAddr)
 			ch <- prometheus.MustNewConstMetric(
 				lanDHCPHostDesc,
 				prometheus.GaugeValue,
@@ -319,7 +971,32 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 				host.MACAddr,
 				host.HostName,
 			)
+
+			isReserved := 0.0
+			if reserved[normalizeMAC(host.MACAddr)] {
+				isReserved = 1
+			}
+			ch <- prometheus.MustNewConstMetric(lanDHCPHostReservedDesc, prometheus.GaugeValue, isReserved, host.MACAddr, host.IPAddr, host.HostName)
+		}
+	}
+
+	// Check the reservations only after every source of seen devices has been loaded
+	for _, binding := range lanDHCPBindings {
+		lastSeen, stale := c.reservations.Check(binding.MACAddr, binding.IPAddr)
+		isStale := 0.0
+		if stale {
+			isStale = 1
+		}
+		if !lastSeen.IsZero() {
+			ch <- prometheus.MustNewConstMetric(lanDHCPReservationLastSeenDesc, prometheus.GaugeValue, float64(lastSeen.Unix()), binding.MACAddr, binding.IPAddr)
 		}
+		ch <- prometheus.MustNewConstMetric(lanDHCPReservationStaleDesc, prometheus.GaugeValue, isStale, binding.MACAddr, binding.IPAddr)
+	}
+	if reservationsLoaded {
+		c.reservations.Prune(reserved)
+	}
+	if err := c.reservations.Save(); err != nil {
+		log.Printf("Error saving DHCP reservation state: %v", err)
 	}
 
 	lanDHCPSettings, err := c.session.LoadLanDHCPSettings()
@@ -347,4 +1024,165 @@ func (c *ONTCollector) Collect(ch chan<- prometheus.Metric) {
 			lanDHCPSettings.IPv6AssignLANIP,
 		)
 	}
+
+	// Diagnostics, probed in the background
+	if c.diag != nil {
+		pings, traceroutes := c.diag.Results()
+		for _, ping := range pings {
+			if loss, ok := ping.LossRatio(); ok {
+				ch <- prometheus.MustNewConstMetric(diagPingLossDesc, prometheus.GaugeValue, loss, ping.Target)
+			}
+			if ping.SuccessCount == 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(diagPingRTTDesc, prometheus.GaugeValue, ping.MinRTT.Seconds(), ping.Target, "min")
+			ch <- prometheus.MustNewConstMetric(diagPingRTTDesc, prometheus.GaugeValue, ping.AvgRTT.Seconds(), ping.Target, "avg")
+			ch <- prometheus.MustNewConstMetric(diagPingRTTDesc, prometheus.GaugeValue, ping.MaxRTT.Seconds(), ping.Target, "max")
+		}
+		for _, trace := range traceroutes {
+			ch <- prometheus.MustNewConstMetric(diagTracerouteHopsDesc, prometheus.GaugeValue, float64(len(trace.Hops)), trace.Target)
+			for _, hop := range trace.Hops {
+				if len(hop.RTTs) == 0 {
+					continue
+				}
+				var total time.Duration
+				for _, rtt := range hop.RTTs {
+					total += rtt
+				}
+				ch <- prometheus.MustNewConstMetric(
+					diagTracerouteHopRTTDesc,
+					prometheus.GaugeValue,
+					(total / time.Duration(len(hop.RTTs))).Seconds(),
+					trace.Target, strconv.Itoa(hop.Hop), hop.Host, hop.Address,
+				)
+			}
+		}
+	}
+}
+
+// collectInventory collects the configuration pages that rarely change, see
+// inventoryCache. It reports whether every page loaded.
+func (c *ONTCollector) collectInventory(ch chan<- prometheus.Metric) bool {
+	complete := true
+
+	portForwardRules, err := c.session.LoadPortForwarding()
+	if err != nil {
+		log.Printf("Error loading port forwarding rules: %v", err)
+		complete = false
+	} else {
+		for _, rule := range portForwardRules {
+			ch <- prometheus.MustNewConstMetric(
+				portForwardRuleDesc,
+				prometheus.GaugeValue,
+				float64(rule.Enable),
+				rule.InstID,
+				rule.Name,
+				rule.Protocol,
+				rule.WANCName,
+				portRange(rule.ExternalPortStart, rule.ExternalPortEnd),
+				rule.InternalClient,
+				portRange(rule.InternalPortStart, rule.InternalPortEnd),
+			)
+		}
+	}
+
+	dmz, err := c.session.LoadDMZ()
+	if err != nil {
+		log.Printf("Error loading DMZ settings: %v", err)
+		complete = false
+	} else {
+		ch <- prometheus.MustNewConstMetric(dmzInfoDesc, prometheus.GaugeValue, float64(dmz.Enable), dmz.WANCName, dmz.InternalClient)
+	}
+
+	firewall, err := c.session.LoadFirewallSettings()
+	if err != nil {
+		log.Printf("Error loading firewall settings: %v", err)
+		complete = false
+	} else {
+		ch <- prometheus.MustNewConstMetric(firewallInfoDesc, prometheus.GaugeValue, 1, firewall.Level)
+		ch <- prometheus.MustNewConstMetric(firewallAntiAttackEnabledDesc, prometheus.GaugeValue, float64(firewall.AntiAttackEnable))
+	}
+
+	algSettings, err := c.session.LoadALGSettings()
+	if err != nil {
+		log.Printf("Error loading ALG settings: %v", err)
+		complete = false
+	} else {
+		for alg, enable := range algSettings {
+			ch <- prometheus.MustNewConstMetric(algEnabledDesc, prometheus.GaugeValue, float64(enable), alg)
+		}
+	}
+
+	serviceRules, err := c.session.LoadServiceControl()
+	if err != nil {
+		log.Printf("Error loading service access control: %v", err)
+		complete = false
+	} else {
+		for _, rule := range serviceRules {
+			ch <- prometheus.MustNewConstMetric(
+				serviceAccessDesc,
+				prometheus.GaugeValue,
+				float64(rule.Enable),
+				rule.InstID, rule.Service, rule.Interface, rule.SourceIPFrom, rule.SourceIPTo,
+			)
+		}
+	}
+
+	tr069Status, err := c.session.LoadTR069Status()
+	if err != nil {
+		log.Printf("Error loading TR-069 status: %v", err)
+		complete = false
+	} else {
+		ch <- prometheus.MustNewConstMetric(
+			tr069InfoDesc,
+			prometheus.GaugeValue,
+			1,
+			tr069Status.ACSURL,
+			strconv.Itoa(tr069Status.PeriodicInformEnable),
+			strconv.Itoa(tr069Status.ConnectionRequestEnabled),
+			tr069Status.LastInformResult,
+			tr069Status.LastConnectionRequestResult,
+		)
+		ch <- prometheus.MustNewConstMetric(tr069PeriodicInformIntervalDesc, prometheus.GaugeValue, float64(tr069Status.PeriodicInformInterval))
+		if !tr069Status.LastInformTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(tr069LastInformDesc, prometheus.GaugeValue, float64(tr069Status.LastInformTime.Unix()))
+		}
+		if !tr069Status.LastConnectionRequestTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(tr069LastConnectionRequestDesc, prometheus.GaugeValue, float64(tr069Status.LastConnectionRequestTime.Unix()))
+		}
+	}
+
+	macFilters, err := c.session.LoadMacFilters()
+	if err != nil {
+		log.Printf("Error loading MAC filters: %v", err)
+		complete = false
+	} else {
+		ch <- prometheus.MustNewConstMetric(macFilterEnabledDesc, prometheus.GaugeValue, float64(macFilters.WlanEnable), "wlan")
+		ch <- prometheus.MustNewConstMetric(macFilterEnabledDesc, prometheus.GaugeValue, float64(macFilters.LanEnable), "lan")
+		for _, rule := range macFilters.Rules {
+			ch <- prometheus.MustNewConstMetric(
+				macFilterInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				rule.MACAddress, rule.InstID, rule.Name, rule.Scope, rule.Mode,
+			)
+		}
+	}
+
+	parentalControl, err := c.session.LoadParentalControl()
+	if err != nil {
+		log.Printf("Error loading parental control: %v", err)
+		complete = false
+	} else {
+		for _, rule := range parentalControl {
+			ch <- prometheus.MustNewConstMetric(
+				parentalControlInfoDesc,
+				prometheus.GaugeValue,
+				float64(rule.Enable),
+				rule.MACAddress, rule.InstID, rule.Name, rule.Days, rule.StartTime, rule.EndTime,
+			)
+		}
+	}
+
+	return complete
 }
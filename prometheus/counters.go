@@ -0,0 +1,50 @@
+package prometheus
+
+import "sync"
+
+// counterTracker keeps counters reported by the ONT monotonic across reboots.
+// The ONT zeroes its statistics when it restarts, which is detected by the
+// device uptime going backwards; the last values seen before the reboot are
+// then carried over as an offset.
+type counterTracker struct {
+	mu     sync.Mutex
+	uptime int
+	last   map[string]float64
+	offset map[string]float64
+}
+
+func newCounterTracker() *counterTracker {
+	return &counterTracker{
+		last:   make(map[string]float64),
+		offset: make(map[string]float64),
+	}
+}
+
+// ObserveUptime records the current device uptime and folds the last counter
+// values into their offsets when a reboot is detected.
+func (t *counterTracker) ObserveUptime(uptime int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if uptime < t.uptime {
+		for key, value := range t.last {
+			t.offset[key] += value
+			t.last[key] = 0
+		}
+	}
+	t.uptime = uptime
+}
+
+// Value returns the monotonic value of the counter identified by key.
+func (t *counterTracker) Value(key string, value int) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v := float64(value)
+	// A counter can also be cleared without a reboot, e.g. from the web UI
+	if v < t.last[key] {
+		t.offset[key] += t.last[key]
+	}
+	t.last[key] = v
+	return t.offset[key] + v
+}
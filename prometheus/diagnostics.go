@@ -0,0 +1,123 @@
+package prometheus
+
+import (
+	"cmp"
+	"errors"
+	"log"
+	"os"
+	"prometheus_F670L/ont"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiagProber periodically pings and traces the configured targets from the
+// ONT itself, keeping the latest result per target for the collector. It is
+// started by the caller with Run.
+type DiagProber struct {
+	session           *ont.Session
+	pingTargets       []string
+	tracerouteTargets []string
+	pingCount         int
+	interval          time.Duration
+
+	mu          sync.Mutex
+	pings       map[string]*ont.PingResult
+	traceroutes map[string]*ont.TracerouteResult
+}
+
+func splitTargets(val string) []string {
+	var targets []string
+	for _, target := range strings.Split(val, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// NewDiagProber creates a prober for the targets in ONT_DIAG_PING_TARGETS and
+// ONT_DIAG_TRACEROUTE_TARGETS, or returns nil when none are configured.
+func NewDiagProber(session *ont.Session) *DiagProber {
+	pingTargets := splitTargets(os.Getenv("ONT_DIAG_PING_TARGETS"))
+	tracerouteTargets := splitTargets(os.Getenv("ONT_DIAG_TRACEROUTE_TARGETS"))
+	if len(pingTargets) == 0 && len(tracerouteTargets) == 0 {
+		return nil
+	}
+
+	interval, _ := strconv.Atoi(cmp.Or(os.Getenv("ONT_DIAG_INTERVAL"), "300"))
+	pingCount, _ := strconv.Atoi(cmp.Or(os.Getenv("ONT_DIAG_PING_COUNT"), "4"))
+
+	return &DiagProber{
+		session:           session,
+		pingTargets:       pingTargets,
+		tracerouteTargets: tracerouteTargets,
+		pingCount:         max(pingCount, 1),
+		interval:          time.Duration(max(interval, 1)) * time.Second,
+		pings:             make(map[string]*ont.PingResult),
+		traceroutes:       make(map[string]*ont.TracerouteResult),
+	}
+}
+
+// Run probes all targets every interval until the process exits.
+func (p *DiagProber) Run() {
+	for {
+		// Ping and Traceroute lock the session only around each request, so
+		// scrapes aren't blocked while the ONT runs the diagnosis
+		for _, target := range p.pingTargets {
+			result, err := p.session.Ping(target, p.pingCount)
+			var diagErr *ont.DiagnosisError
+			if errors.As(err, &diagErr) {
+				// The ONT ran the diagnosis but could not reach the target
+				result = &ont.PingResult{Target: target, State: diagErr.State, FailureCount: p.pingCount}
+			} else if err != nil {
+				// Failures on the exporter side say nothing about the target
+				log.Printf("[Diag] Error pinging %s: %v", target, err)
+			}
+			p.mu.Lock()
+			if result != nil {
+				p.pings[target] = result
+			} else {
+				delete(p.pings, target)
+			}
+			p.mu.Unlock()
+		}
+
+		for _, target := range p.tracerouteTargets {
+			result, err := p.session.Traceroute(target)
+			var diagErr *ont.DiagnosisError
+			if errors.As(err, &diagErr) {
+				// Replace the previous hops, so a broken path is visible
+				result = &ont.TracerouteResult{Target: target, State: diagErr.State}
+			} else if err != nil {
+				log.Printf("[Diag] Error tracing route to %s: %v", target, err)
+			}
+			p.mu.Lock()
+			if result != nil {
+				p.traceroutes[target] = result
+			} else {
+				delete(p.traceroutes, target)
+			}
+			p.mu.Unlock()
+		}
+
+		time.Sleep(p.interval)
+	}
+}
+
+// Results returns the latest ping and traceroute results.
+func (p *DiagProber) Results() ([]ont.PingResult, []ont.TracerouteResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var pings []ont.PingResult
+	for _, result := range p.pings {
+		pings = append(pings, *result)
+	}
+	var traceroutes []ont.TracerouteResult
+	for _, result := range p.traceroutes {
+		traceroutes = append(traceroutes, *result)
+	}
+	return pings, traceroutes
+}
@@ -0,0 +1,79 @@
+package prometheus
+
+import (
+	"cmp"
+	"log"
+	"os"
+	"prometheus_F670L/ont"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// eventCounter counts classified ONT system log events across scrapes. The
+// log is either fed in by the log forwarding tailer or loaded by the counter
+// itself every ONT_LOG_INTERVAL seconds, never on every scrape.
+type eventCounter struct {
+	mu       sync.Mutex
+	cursor   ont.LogCursor
+	counts   map[string]int
+	interval time.Duration
+	lastLoad time.Time
+	fed      bool
+}
+
+func newEventCounter() *eventCounter {
+	counts := make(map[string]int)
+	for _, eventType := range ont.LogEventTypes() {
+		counts[eventType] = 0
+	}
+
+	intervalString := cmp.Or(os.Getenv("ONT_LOG_INTERVAL"), "60")
+	interval, _ := strconv.Atoi(intervalString)
+
+	return &eventCounter{
+		counts:   counts,
+		interval: time.Duration(max(interval, 1)) * time.Second,
+	}
+}
+
+// Observe counts the events among the entries not seen by a previous call.
+// Once entries are fed in, the counter stops loading the log itself.
+func (e *eventCounter) Observe(entries []ont.LogEntry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.fed = true
+	e.observe(entries)
+}
+
+// Counts returns a copy of the totals, loading the system log first when
+// nothing feeds the counter and the interval has elapsed.
+func (e *eventCounter) Counts(session *ont.Session) map[string]int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.fed && (e.lastLoad.IsZero() || time.Since(e.lastLoad) >= e.interval) {
+		e.lastLoad = time.Now()
+		entries, err := session.LoadSystemLog()
+		if err != nil {
+			log.Printf("Error loading system log: %v", err)
+		} else {
+			e.observe(entries)
+		}
+	}
+
+	counts := make(map[string]int, len(e.counts))
+	for eventType, count := range e.counts {
+		counts[eventType] = count
+	}
+	return counts
+}
+
+func (e *eventCounter) observe(entries []ont.LogEntry) {
+	for _, entry := range e.cursor.Next(entries) {
+		if eventType := ont.ClassifyLogEntry(entry); eventType != "" {
+			e.counts[eventType]++
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package prometheus
+
+import (
+	"cmp"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// inventoryCache keeps the metrics of configuration pages that rarely change
+// (port forwarding, DMZ, firewall, ALG, service access, TR-069, MAC filters,
+// parental control) so that they are only loaded from the ONT every
+// ONT_INVENTORY_INTERVAL seconds instead of on every scrape.
+type inventoryCache struct {
+	mu       sync.Mutex
+	interval time.Duration
+	lastLoad time.Time
+	metrics  []prometheus.Metric
+}
+
+func newInventoryCache() *inventoryCache {
+	intervalString := cmp.Or(os.Getenv("ONT_INVENTORY_INTERVAL"), "900")
+	interval, _ := strconv.Atoi(intervalString)
+
+	return &inventoryCache{
+		interval: time.Duration(interval) * time.Second,
+	}
+}
+
+// Metrics returns the cached metrics, calling collect again when the interval
+// has elapsed. A round in which collect reports a failed page isn't cached,
+// so it is retried on the next scrape like the other loaders. A zero
+// interval disables caching.
+func (c *inventoryCache) Metrics(collect func(ch chan<- prometheus.Metric) bool) []prometheus.Metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.interval > 0 && !c.lastLoad.IsZero() && time.Since(c.lastLoad) < c.interval {
+		return c.metrics
+	}
+
+	ch := make(chan prometheus.Metric)
+	done := make(chan []prometheus.Metric)
+	go func() {
+		var metrics []prometheus.Metric
+		for m := range ch {
+			metrics = append(metrics, m)
+		}
+		done <- metrics
+	}()
+	complete := collect(ch)
+	close(ch)
+	metrics := <-done
+
+	if complete {
+		c.lastLoad = time.Now()
+		c.metrics = metrics
+	} else {
+		c.lastLoad = time.Time{}
+	}
+	return metrics
+}
@@ -67,7 +67,7 @@ var (
 	wlanClientStatusDesc = prometheus.NewDesc(
 		"ont_wlan_client_status",
 		"WLAN Client Status",
-		[]string{"hostname", "ip", "ipv6", "mac", "alias", "essid", "rssi", "tx_rate", "rx_rate", "snr", "noise", "link_time", "mode", "mcs", "band"},
+		[]string{"hostname", "ip", "ipv6", "mac", "alias", "essid", "rssi", "tx_rate", "rx_rate", "snr", "noise", "link_time", "mode", "mcs", "band", "mesh_agent"},
 		nil,
 	)
 
@@ -88,6 +88,19 @@ var (
 		nil,
 	)
 
+	wanUpDesc = prometheus.NewDesc(
+		"ont_wan_up",
+		"Whether the WAN connection is connected",
+		[]string{"wan_name", "inst_id"},
+		nil,
+	)
+	wanUptimeDesc = prometheus.NewDesc(
+		"ont_wan_uptime_seconds",
+		"WAN connection uptime in seconds",
+		[]string{"wan_name", "inst_id"},
+		nil,
+	)
+
 	wlanAPStatusDesc = prometheus.NewDesc(
 		"ont_wlan_ap_status",
 		"WLAN AP status and statistics",
@@ -114,4 +127,600 @@ var (
 		},
 		nil,
 	)
+
+	// PON metrics
+	ponOnuStateDesc = prometheus.NewDesc(
+		"ont_pon_onu_state",
+		"GPON ONU activation state (O1-O7), 1 for the current state",
+		[]string{"state"},
+		nil,
+	)
+	ponRegisteredDesc = prometheus.NewDesc(
+		"ont_pon_registered",
+		"Whether the ONU is registered on the OLT (state O5)",
+		nil,
+		nil,
+	)
+	ponStatusDesc = prometheus.NewDesc(
+		"ont_pon_status",
+		"PON link and authentication status",
+		[]string{"link_status", "loid_auth_status", "password_auth_status"},
+		nil,
+	)
+	ponFECCorrectedDesc = prometheus.NewDesc(
+		"ont_pon_fec_corrected_total",
+		"Number of FEC codewords corrected on the PON link",
+		nil,
+		nil,
+	)
+	ponFECUncorrectableDesc = prometheus.NewDesc(
+		"ont_pon_fec_uncorrectable_total",
+		"Number of FEC codewords that could not be corrected on the PON link",
+		nil,
+		nil,
+	)
+	ponBIPErrorsDesc = prometheus.NewDesc(
+		"ont_pon_bip_errors_total",
+		"Number of BIP errors on the PON link",
+		nil,
+		nil,
+	)
+	ponHECErrorsDesc = prometheus.NewDesc(
+		"ont_pon_hec_errors_total",
+		"Number of GEM header HEC errors on the PON link",
+		nil,
+		nil,
+	)
+	ponDroppedGEMFramesDesc = prometheus.NewDesc(
+		"ont_pon_gem_frames_dropped_total",
+		"Number of dropped GEM frames on the PON link",
+		nil,
+		nil,
+	)
+
+	// IPv6 metrics
+	wanIPv6UpDesc = prometheus.NewDesc(
+		"ont_wan_ipv6_up",
+		"Whether the WAN connection has IPv6 connectivity",
+		[]string{"wan_name", "inst_id"},
+		nil,
+	)
+	wanIPv6InfoDesc = prometheus.NewDesc(
+		"ont_wan_ipv6_info",
+		"IPv6 WAN addressing info",
+		[]string{"wan_name", "inst_id", "address", "gateway", "dns1", "dns2"},
+		nil,
+	)
+	ipv6PrefixInfoDesc = prometheus.NewDesc(
+		"ont_ipv6_prefix_info",
+		"IPv6 prefixes delegated to the WAN connections and advertised on the LAN",
+		[]string{"scope", "wan_name", "inst_id", "prefix", "origin", "ra_enable", "dhcpv6_mode"},
+		nil,
+	)
+	ipv6PrefixValidLifetimeDesc = prometheus.NewDesc(
+		"ont_ipv6_prefix_valid_lifetime_seconds",
+		"Remaining valid lifetime of the delegated IPv6 prefix in seconds",
+		[]string{"wan_name", "inst_id", "prefix"},
+		nil,
+	)
+	ipv6PrefixPreferredLifetimeDesc = prometheus.NewDesc(
+		"ont_ipv6_prefix_preferred_lifetime_seconds",
+		"Remaining preferred lifetime of the delegated IPv6 prefix in seconds",
+		[]string{"wan_name", "inst_id", "prefix"},
+		nil,
+	)
+
+	// WAN traffic metrics
+	wanBytesDesc = prometheus.NewDesc(
+		"ont_wan_bytes_total",
+		"Number of bytes transmitted/received on the WAN connection",
+		[]string{"direction", "wan_name", "inst_id"},
+		nil,
+	)
+	wanPacketsDesc = prometheus.NewDesc(
+		"ont_wan_packets_total",
+		"Number of packets transmitted/received on the WAN connection",
+		[]string{"direction", "wan_name", "inst_id"},
+		nil,
+	)
+	wanErrorsDesc = prometheus.NewDesc(
+		"ont_wan_errors_total",
+		"Number of errors on the WAN connection",
+		[]string{"direction", "wan_name", "inst_id"},
+		nil,
+	)
+
+	// VoIP metrics
+	voipLineRegisteredDesc = prometheus.NewDesc(
+		"ont_voip_line_registered",
+		"Whether the VoIP line is registered with the SIP registrar",
+		[]string{"line"},
+		nil,
+	)
+	voipLineInfoDesc = prometheus.NewDesc(
+		"ont_voip_line_info",
+		"VoIP line status info",
+		[]string{"line", "number", "registrar", "register_status", "hook_status"},
+		nil,
+	)
+	voipLineOffHookDesc = prometheus.NewDesc(
+		"ont_voip_line_off_hook",
+		"Whether the phone on the VoIP line is off hook",
+		[]string{"line"},
+		nil,
+	)
+	voipCallsDesc = prometheus.NewDesc(
+		"ont_voip_calls_total",
+		"Number of calls on the VoIP line",
+		[]string{"line", "direction"},
+		nil,
+	)
+	voipCallsFailedDesc = prometheus.NewDesc(
+		"ont_voip_calls_failed_total",
+		"Number of failed calls on the VoIP line",
+		[]string{"line"},
+		nil,
+	)
+
+	// USB metrics
+	usbDeviceInfoDesc = prometheus.NewDesc(
+		"ont_usb_device_info",
+		"USB device attached to the ONT",
+		[]string{"inst_id", "port", "type", "manufacturer", "product", "serial_number"},
+		nil,
+	)
+	usbStorageSizeDesc = prometheus.NewDesc(
+		"ont_usb_storage_size_bytes",
+		"Size of the mounted USB storage partition in bytes",
+		[]string{"device", "partition", "mount_point", "filesystem"},
+		nil,
+	)
+	usbStorageFreeDesc = prometheus.NewDesc(
+		"ont_usb_storage_free_bytes",
+		"Free space on the mounted USB storage partition in bytes",
+		[]string{"device", "partition", "mount_point", "filesystem"},
+		nil,
+	)
+
+	// WLAN radio metrics
+	wlanRadioInfoDesc = prometheus.NewDesc(
+		"ont_wlan_radio_info",
+		"WLAN radio configuration",
+		[]string{"inst_id", "band", "standard", "channel_width"},
+		nil,
+	)
+	wlanRadioEnabledDesc = prometheus.NewDesc(
+		"ont_wlan_radio_enabled",
+		"Whether the WLAN radio is enabled",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioChannelDesc = prometheus.NewDesc(
+		"ont_wlan_radio_channel",
+		"Channel the WLAN radio is operating on",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioAutoChannelDesc = prometheus.NewDesc(
+		"ont_wlan_radio_auto_channel",
+		"Whether automatic channel selection is enabled on the WLAN radio",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioTxPowerDesc = prometheus.NewDesc(
+		"ont_wlan_radio_transmit_power_percent",
+		"Transmit power of the WLAN radio in percent",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioBeaconIntervalDesc = prometheus.NewDesc(
+		"ont_wlan_radio_beacon_interval_tu",
+		"Beacon interval of the WLAN radio in time units (1.024 ms)",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioChannelUtilizationDesc = prometheus.NewDesc(
+		"ont_wlan_radio_channel_utilization_percent",
+		"Channel utilisation seen by the WLAN radio in percent",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+	wlanRadioInterferenceDesc = prometheus.NewDesc(
+		"ont_wlan_radio_interference_percent",
+		"Interference seen by the WLAN radio in percent",
+		[]string{"inst_id", "band"},
+		nil,
+	)
+
+	// WLAN neighbour scan metrics
+	wlanNeighborSignalDesc = prometheus.NewDesc(
+		"ont_wlan_neighbor_signal_dbm",
+		"Signal strength of a neighbouring WLAN network in dBm",
+		[]string{"ssid", "bssid", "channel", "band", "security"},
+		nil,
+	)
+	wlanNeighborChannelDesc = prometheus.NewDesc(
+		"ont_wlan_neighbor_channel_networks",
+		"Number of neighbouring WLAN networks per channel",
+		[]string{"channel", "band"},
+		nil,
+	)
+
+	// Mesh metrics
+	meshAgentInfoDesc = prometheus.NewDesc(
+		"ont_mesh_agent_info",
+		"EasyMesh agent info",
+		[]string{"inst_id", "mac", "name", "role", "ip", "parent_mac", "backhaul_type"},
+		nil,
+	)
+	meshBackhaulRateDesc = prometheus.NewDesc(
+		"ont_mesh_backhaul_rate_bps",
+		"EasyMesh agent backhaul link rate in bits per second",
+		[]string{"mac", "name", "backhaul_type"},
+		nil,
+	)
+	meshBackhaulRSSIDesc = prometheus.NewDesc(
+		"ont_mesh_backhaul_rssi_dbm",
+		"EasyMesh agent wireless backhaul signal strength in dBm",
+		[]string{"mac", "name"},
+		nil,
+	)
+	meshClientAgentDesc = prometheus.NewDesc(
+		"ont_mesh_client_agent",
+		"EasyMesh client to agent association",
+		[]string{"mac", "agent_mac", "agent_name", "band"},
+		nil,
+	)
+
+	// ARP and routing metrics
+	arpEntriesDesc = prometheus.NewDesc(
+		"ont_arp_entries",
+		"Number of ARP table entries per interface",
+		[]string{"interface"},
+		nil,
+	)
+	arpEntryInfoDesc = prometheus.NewDesc(
+		"ont_arp_entry_info",
+		"ARP table entry",
+		[]string{"ip", "mac", "interface", "type"},
+		nil,
+	)
+	routeInfoDesc = prometheus.NewDesc(
+		"ont_route_info",
+		"Routing table entry",
+		[]string{"family", "destination", "mask", "gateway", "interface", "metric"},
+		nil,
+	)
+	defaultRouteDesc = prometheus.NewDesc(
+		"ont_default_route_info",
+		"Default route gateway",
+		[]string{"family", "gateway", "interface"},
+		nil,
+	)
+
+	// NAT metrics
+	natSessionsActiveDesc = prometheus.NewDesc(
+		"ont_nat_sessions_active",
+		"Number of active NAT sessions",
+		nil,
+		nil,
+	)
+	natSessionsMaxDesc = prometheus.NewDesc(
+		"ont_nat_sessions_max",
+		"Maximum number of NAT sessions",
+		nil,
+		nil,
+	)
+	natProtocolSessionsDesc = prometheus.NewDesc(
+		"ont_nat_protocol_sessions_active",
+		"Number of active NAT sessions per protocol",
+		[]string{"protocol"},
+		nil,
+	)
+
+	// UPnP metrics
+	upnpPortMappingInfoDesc = prometheus.NewDesc(
+		"ont_upnp_port_mapping_info",
+		"Active UPnP IGD port mapping, value is 1 if the mapping is enabled",
+		[]string{"protocol", "remote_host", "external_port", "internal_client", "internal_port", "description"},
+		nil,
+	)
+	upnpPortMappingLeaseDesc = prometheus.NewDesc(
+		"ont_upnp_port_mapping_lease_seconds",
+		"Lease duration of the UPnP port mapping in seconds, 0 for permanent mappings",
+		[]string{"protocol", "remote_host", "external_port"},
+		nil,
+	)
+	upnpPortMappingsDesc = prometheus.NewDesc(
+		"ont_upnp_port_mappings",
+		"Number of active UPnP port mappings",
+		nil,
+		nil,
+	)
+
+	// Security configuration metrics
+	portForwardRuleDesc = prometheus.NewDesc(
+		"ont_port_forward_rule_info",
+		"Port forwarding (virtual server) rule, value is 1 if the rule is enabled",
+		[]string{"inst_id", "name", "protocol", "wan_name", "external_ports", "internal_client", "internal_ports"},
+		nil,
+	)
+	dmzInfoDesc = prometheus.NewDesc(
+		"ont_dmz_info",
+		"DMZ host, value is 1 if the DMZ is enabled",
+		[]string{"wan_name", "host"},
+		nil,
+	)
+	firewallInfoDesc = prometheus.NewDesc(
+		"ont_firewall_info",
+		"Firewall level",
+		[]string{"level"},
+		nil,
+	)
+	firewallAntiAttackEnabledDesc = prometheus.NewDesc(
+		"ont_firewall_anti_attack_enabled",
+		"Whether the firewall anti-attack protection is enabled",
+		nil,
+		nil,
+	)
+	algEnabledDesc = prometheus.NewDesc(
+		"ont_alg_enabled",
+		"Whether the application layer gateway is enabled",
+		[]string{"alg"},
+		nil,
+	)
+	serviceAccessDesc = prometheus.NewDesc(
+		"ont_service_access_info",
+		"Service access control rule, value is 1 if the service is reachable",
+		[]string{"inst_id", "service", "interface", "source_from", "source_to"},
+		nil,
+	)
+
+	// TR-069 metrics
+	tr069InfoDesc = prometheus.NewDesc(
+		"ont_tr069_info",
+		"TR-069 remote management settings",
+		[]string{"acs_url", "periodic_inform_enable", "connection_request_enable", "last_inform_result", "last_connection_request_result"},
+		nil,
+	)
+	tr069PeriodicInformIntervalDesc = prometheus.NewDesc(
+		"ont_tr069_periodic_inform_interval_seconds",
+		"TR-069 periodic inform interval in seconds",
+		nil,
+		nil,
+	)
+	tr069LastInformDesc = prometheus.NewDesc(
+		"ont_tr069_last_inform_timestamp_seconds",
+		"Unix time of the last TR-069 inform to the ACS",
+		nil,
+		nil,
+	)
+	tr069LastConnectionRequestDesc = prometheus.NewDesc(
+		"ont_tr069_last_connection_request_timestamp_seconds",
+		"Unix time of the last TR-069 connection request from the ACS",
+		nil,
+		nil,
+	)
+
+	// System log metrics
+	eventsDesc = prometheus.NewDesc(
+		"ont_events_total",
+		"Number of known events found in the ONT system log",
+		[]string{"type"},
+		nil,
+	)
+
+	// DDNS metrics
+	ddnsInfoDesc = prometheus.NewDesc(
+		"ont_ddns_info",
+		"Dynamic DNS client settings, value is 1 if the client is enabled",
+		[]string{"provider", "hostname", "wan_name", "registered_ip", "status"},
+		nil,
+	)
+	ddnsLastUpdateSuccessDesc = prometheus.NewDesc(
+		"ont_ddns_last_update_success",
+		"Whether the last dynamic DNS update succeeded",
+		[]string{"hostname"},
+		nil,
+	)
+	ddnsLastUpdateDesc = prometheus.NewDesc(
+		"ont_ddns_last_update_timestamp_seconds",
+		"Unix time of the last dynamic DNS update",
+		[]string{"hostname"},
+		nil,
+	)
+	ddnsIPMatchesWANDesc = prometheus.NewDesc(
+		"ont_ddns_ip_matches_wan",
+		"Whether the IP registered with the dynamic DNS provider is a current WAN address",
+		[]string{"hostname"},
+		nil,
+	)
+
+	// IGMP metrics
+	igmpInfoDesc = prometheus.NewDesc(
+		"ont_igmp_info",
+		"IGMP snooping and proxy settings",
+		[]string{"snooping_enable", "proxy_enable", "upstream_interface"},
+		nil,
+	)
+	igmpGroupsDesc = prometheus.NewDesc(
+		"ont_igmp_groups",
+		"Number of active multicast groups per interface",
+		[]string{"interface"},
+		nil,
+	)
+	igmpGroupInfoDesc = prometheus.NewDesc(
+		"ont_igmp_group_info",
+		"Active multicast group, value is the number of members",
+		[]string{"group", "source", "interface", "member_ports"},
+		nil,
+	)
+
+	// Time metrics
+	ntpSynchronizedDesc = prometheus.NewDesc(
+		"ont_ntp_synchronized",
+		"Whether the ONT clock is synchronised by NTP",
+		nil,
+		nil,
+	)
+	ntpServerDesc = prometheus.NewDesc(
+		"ont_ntp_server_info",
+		"NTP server configured on the ONT",
+		[]string{"server", "priority"},
+		nil,
+	)
+	timeInfoDesc = prometheus.NewDesc(
+		"ont_time_info",
+		"ONT time settings",
+		[]string{"ntp_enable", "status", "time_zone"},
+		nil,
+	)
+	clockSkewDesc = prometheus.NewDesc(
+		"ont_clock_skew_seconds",
+		"Difference between the ONT clock and the exporter clock in seconds",
+		nil,
+		nil,
+	)
+
+	// DHCP reservation metrics
+	lanDHCPReservationDesc = prometheus.NewDesc(
+		"ont_lan_dhcp_reservation_info",
+		"Static DHCP reservation (MAC to IP binding)",
+		[]string{"inst_id", "mac_addr", "ip_addr", "host_name"},
+		nil,
+	)
+	lanDHCPHostReservedDesc = prometheus.NewDesc(
+		"ont_lan_dhcp_host_reserved",
+		"Whether the DHCP client got its address from a static reservation (1) or dynamically (0)",
+		[]string{"mac_addr", "ip_addr", "host_name"},
+		nil,
+	)
+	lanDHCPReservationLastSeenDesc = prometheus.NewDesc(
+		"ont_lan_dhcp_reservation_last_seen_timestamp_seconds",
+		"Unix time the device of the reservation was last seen (to the minute), absent if it was never seen",
+		[]string{"mac_addr", "ip_addr"},
+		nil,
+	)
+	lanDHCPReservationStaleDesc = prometheus.NewDesc(
+		"ont_lan_dhcp_reservation_stale",
+		"Whether the device of the reservation has not been seen for ONT_DHCP_RESERVATION_STALE_DAYS",
+		[]string{"mac_addr", "ip_addr"},
+		nil,
+	)
+
+	// Access control metrics
+	macFilterEnabledDesc = prometheus.NewDesc(
+		"ont_mac_filter_enabled",
+		"Whether MAC filtering is enabled",
+		[]string{"scope"},
+		nil,
+	)
+	macFilterInfoDesc = prometheus.NewDesc(
+		"ont_mac_filter_info",
+		"MAC filter list entry",
+		[]string{"mac", "inst_id", "name", "scope", "mode"},
+		nil,
+	)
+	parentalControlInfoDesc = prometheus.NewDesc(
+		"ont_parental_control_info",
+		"Parental control access schedule, value is 1 if the schedule is enabled",
+		[]string{"mac", "inst_id", "name", "days", "start_time", "end_time"},
+		nil,
+	)
+
+	// WLAN AP security metrics
+	wlanAPIsolationDesc = prometheus.NewDesc(
+		"ont_wlan_ap_isolation_enabled",
+		"Whether clients of the WLAN AP are isolated from each other and the LAN",
+		[]string{"inst_id", "essid", "band"},
+		nil,
+	)
+	wlanAPGuestDesc = prometheus.NewDesc(
+		"ont_wlan_ap_guest",
+		"Whether the WLAN AP is a guest network",
+		[]string{"inst_id", "essid", "band"},
+		nil,
+	)
+	wlanAPHiddenDesc = prometheus.NewDesc(
+		"ont_wlan_ap_hidden",
+		"Whether the WLAN AP hides its SSID",
+		[]string{"inst_id", "essid", "band"},
+		nil,
+	)
+	wlanAPMaxClientsDesc = prometheus.NewDesc(
+		"ont_wlan_ap_max_clients",
+		"Maximum number of clients allowed on the WLAN AP",
+		[]string{"inst_id", "essid", "band"},
+		nil,
+	)
+	wlanAPWPSEnabledDesc = prometheus.NewDesc(
+		"ont_wlan_ap_wps_enabled",
+		"Whether WPS is enabled on the WLAN AP",
+		[]string{"inst_id", "essid", "band", "mode"},
+		nil,
+	)
+	wlanAPWPSPinEnabledDesc = prometheus.NewDesc(
+		"ont_wlan_ap_wps_pin_enabled",
+		"Whether the WPS device PIN is enabled on the WLAN AP",
+		[]string{"inst_id", "essid", "band"},
+		nil,
+	)
+
+	// Diagnostics metrics
+	diagPingRTTDesc = prometheus.NewDesc(
+		"ont_diag_ping_rtt_seconds",
+		"Round trip time of pings sent from the ONT in seconds",
+		[]string{"target", "stat"},
+		nil,
+	)
+	diagPingLossDesc = prometheus.NewDesc(
+		"ont_diag_ping_loss_ratio",
+		"Share of pings sent from the ONT that got no reply",
+		[]string{"target"},
+		nil,
+	)
+	diagTracerouteHopsDesc = prometheus.NewDesc(
+		"ont_diag_traceroute_hops",
+		"Number of hops from the ONT to the traceroute target",
+		[]string{"target"},
+		nil,
+	)
+	diagTracerouteHopRTTDesc = prometheus.NewDesc(
+		"ont_diag_traceroute_hop_rtt_seconds",
+		"Average round trip time to a traceroute hop from the ONT in seconds",
+		[]string{"target", "hop", "host", "address"},
+		nil,
+	)
+
+	// Loopback detection metrics
+	lanLoopDetectionEnabledDesc = prometheus.NewDesc(
+		"ont_lan_loop_detection_enabled",
+		"Whether LAN loopback detection is enabled on the ONT",
+		nil,
+		nil,
+	)
+	lanLoopDetectionRecoveryIntervalDesc = prometheus.NewDesc(
+		"ont_lan_loop_detection_recovery_interval_seconds",
+		"Time after which a port isolated because of a loop is re-enabled, 0 if it stays shut down",
+		nil,
+		nil,
+	)
+	lanPortLoopDetectionEnabledDesc = prometheus.NewDesc(
+		"ont_lan_port_loop_detection_enabled",
+		"Whether loopback detection is enabled on the LAN port",
+		[]string{"port"},
+		nil,
+	)
+	lanPortLoopDetectedDesc = prometheus.NewDesc(
+		"ont_lan_port_loop_detected",
+		"Whether a switching loop is currently detected on the LAN port",
+		[]string{"port"},
+		nil,
+	)
+	lanPortIsolatedDesc = prometheus.NewDesc(
+		"ont_lan_port_isolated",
+		"Whether the LAN port is shut down because of a detected loop",
+		[]string{"port"},
+		nil,
+	)
 )
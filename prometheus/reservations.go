@@ -0,0 +1,173 @@
+package prometheus
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reservationTracker remembers when the device of each static DHCP reservation
+// was last seen on the LAN. Reservations whose device was never seen become
+// stale ONT_DHCP_RESERVATION_STALE_DAYS after they were first checked. When
+// ONT_DHCP_RESERVATION_STATE_FILE is set both times are kept there, so they
+// survive exporter restarts.
+type reservationTracker struct {
+	mu         sync.Mutex
+	staleAfter time.Duration
+	stateFile  string
+	state      reservationState
+	stale      map[string]bool
+	// saved is the state last read from or written to the state file
+	saved []byte
+}
+
+type reservationState struct {
+	LastSeen map[string]time.Time `json:"last_seen"`
+	Tracked  map[string]time.Time `json:"tracked"`
+}
+
+func newReservationTracker() *reservationTracker {
+	daysString := cmp.Or(os.Getenv("ONT_DHCP_RESERVATION_STALE_DAYS"), "30")
+	days, _ := strconv.Atoi(daysString)
+
+	t := &reservationTracker{
+		staleAfter: time.Duration(days) * 24 * time.Hour,
+		stateFile:  os.Getenv("ONT_DHCP_RESERVATION_STATE_FILE"),
+		state: reservationState{
+			LastSeen: make(map[string]time.Time),
+			Tracked:  make(map[string]time.Time),
+		},
+		stale: make(map[string]bool),
+	}
+	if err := t.load(); err != nil {
+		log.Printf("[DHCP] Error loading reservation state: %v", err)
+	}
+	return t
+}
+
+func normalizeMAC(mac string) string {
+	return strings.ToLower(strings.ReplaceAll(mac, "-", ":"))
+}
+
+// Seen marks the device with the given MAC address as present on the LAN.
+func (t *reservationTracker) Seen(mac string) {
+	if mac == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Minute precision is plenty for staleness measured in days, and keeps the
+	// state file from being rewritten on every scrape
+	t.state.LastSeen[normalizeMAC(mac)] = time.Now().Truncate(time.Minute)
+}
+
+// Check returns when the reserved device was last seen, the zero time if it
+// never was, and whether the reservation is stale, logging reservations that
+// just became stale.
+func (t *reservationTracker) Check(mac, ip string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := normalizeMAC(mac)
+	tracked, ok := t.state.Tracked[key]
+	if !ok {
+		tracked = time.Now()
+		t.state.Tracked[key] = tracked
+	}
+
+	lastSeen := t.state.LastSeen[key]
+	since := lastSeen
+	if since.IsZero() {
+		since = tracked
+	}
+
+	stale := t.staleAfter > 0 && time.Since(since) > t.staleAfter
+	if stale && !t.stale[key] {
+		if lastSeen.IsZero() {
+			log.Printf("[DHCP] Reservation %s -> %s has never been seen since %s", mac, ip, tracked.Format(time.RFC3339))
+		} else {
+			log.Printf("[DHCP] Reservation %s -> %s has not been seen since %s", mac, ip, lastSeen.Format(time.RFC3339))
+		}
+	}
+	t.stale[key] = stale
+	return lastSeen, stale
+}
+
+func (t *reservationTracker) load() error {
+	if t.stateFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(t.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &t.state); err != nil {
+		return err
+	}
+	t.saved = data
+	if t.state.LastSeen == nil {
+		t.state.LastSeen = make(map[string]time.Time)
+	}
+	if t.state.Tracked == nil {
+		t.state.Tracked = make(map[string]time.Time)
+	}
+	return nil
+}
+
+// Prune forgets all devices but the reserved ones, so the state doesn't grow
+// with every device ever seen on the LAN or reservations that were removed.
+func (t *reservationTracker) Prune(reserved map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, times := range []map[string]time.Time{t.state.LastSeen, t.state.Tracked} {
+		for key := range times {
+			if !reserved[key] {
+				delete(times, key)
+			}
+		}
+	}
+	for key := range t.stale {
+		if !reserved[key] {
+			delete(t.stale, key)
+		}
+	}
+}
+
+// Save writes the state file, if one is configured and the state changed. It
+// is written to a temporary file first, so a crash can't leave it truncated.
+func (t *reservationTracker) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stateFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(t.state)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, t.saved) {
+		return nil
+	}
+	tmpFile := t.stateFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, t.stateFile); err != nil {
+		return err
+	}
+	t.saved = data
+	return nil
+}
@@ -0,0 +1,49 @@
+package prometheus
+
+import (
+	"log"
+	"prometheus_F670L/ont"
+	"strconv"
+	"sync"
+)
+
+// upnpWatcher logs UPnP port mappings appearing and disappearing between scrapes.
+type upnpWatcher struct {
+	mu       sync.Mutex
+	loaded   bool
+	mappings map[string]ont.UPnPPortMapping
+}
+
+func newUPnPWatcher() *upnpWatcher {
+	return &upnpWatcher{
+		mappings: make(map[string]ont.UPnPPortMapping),
+	}
+}
+
+func upnpMappingKey(m ont.UPnPPortMapping) string {
+	return m.Protocol + "/" + m.RemoteHost + ":" + strconv.Itoa(m.ExternalPort)
+}
+
+// Observe compares the current mappings with the previous scrape. The first
+// call only records the mappings, so existing ones aren't reported as new.
+func (w *upnpWatcher) Observe(mappings []ont.UPnPPortMapping) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := make(map[string]ont.UPnPPortMapping)
+	for _, m := range mappings {
+		key := upnpMappingKey(m)
+		current[key] = m
+		if _, ok := w.mappings[key]; w.loaded && !ok {
+			log.Printf("[UPnP] New port mapping: %s %d -> %s:%d (%s)", m.Protocol, m.ExternalPort, m.InternalClient, m.InternalPort, m.Description)
+		}
+	}
+	for key, m := range w.mappings {
+		if _, ok := current[key]; !ok {
+			log.Printf("[UPnP] Port mapping removed: %s %d -> %s:%d (%s)", m.Protocol, m.ExternalPort, m.InternalClient, m.InternalPort, m.Description)
+		}
+	}
+
+	w.mappings = current
+	w.loaded = true
+}
@@ -0,0 +1,53 @@
+package prometheus
+
+import (
+	"cmp"
+	"log"
+	"os"
+	"prometheus_F670L/ont"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// wlanScanner caches the result of the neighbour AP scan, which disrupts
+// connected clients and therefore only runs every ONT_WLAN_SCAN_INTERVAL seconds.
+type wlanScanner struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	lastScan  time.Time
+	neighbors []ont.WlanNeighbor
+}
+
+func newWlanScanner() *wlanScanner {
+	intervalString := cmp.Or(os.Getenv("ONT_WLAN_SCAN_INTERVAL"), "3600")
+	interval, _ := strconv.Atoi(intervalString)
+
+	return &wlanScanner{
+		interval: time.Duration(interval) * time.Second,
+	}
+}
+
+// Neighbors returns the cached neighbours, scanning again when the interval
+// has elapsed. A zero interval disables scanning.
+func (s *wlanScanner) Neighbors(session *ont.Session) []ont.WlanNeighbor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.interval <= 0 {
+		return nil
+	}
+	if !s.lastScan.IsZero() && time.Since(s.lastScan) < s.interval {
+		return s.neighbors
+	}
+
+	// Remember failed scans too, so an unsupported page isn't retried every scrape
+	s.lastScan = time.Now()
+	neighbors, err := session.LoadWlanNeighbors()
+	if err != nil {
+		log.Printf("Error loading WLAN neighbors: %v", err)
+		return s.neighbors
+	}
+	s.neighbors = neighbors
+	return s.neighbors
+}